@@ -0,0 +1,186 @@
+// The codec package encodes wrapped values with their flavor, so that a precisely flavored
+// [lift.Sym] can be recovered from bytes.
+//
+// Types are encoded by name. A type is given a name with [Register];
+// predeclared types are registered by default, with their Go names.
+//
+// [Marshal] and [Unmarshal] encode a wrapped value as a JSON object,
+// {"type": "<name>", "value": ...}. An [Any] field encodes a wrapped value
+// in this way within a struct.
+//
+// An [Encoder] and [Decoder] encode a stream of wrapped values of varied flavors,
+// in length-prefixed binary frames.
+package codec
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/AndrewHarrisSPU/lift"
+)
+
+// REGISTRY
+
+// ErrUnknownType is reported when a type or type name isn't registered.
+var ErrUnknownType = errors.New("unknown type")
+
+var registry = struct {
+	sync.RWMutex
+	byName map[string]reflect.Type
+	byType map[reflect.Type]string
+	binary map[reflect.Type]binaryFuncs
+}{
+	byName: make(map[string]reflect.Type),
+	byType: make(map[reflect.Type]string),
+	binary: make(map[reflect.Type]binaryFuncs),
+}
+
+func init() {
+	Register[bool]("bool")
+	Register[string]("string")
+	Register[int]("int")
+	Register[int8]("int8")
+	Register[int16]("int16")
+	Register[int32]("int32")
+	Register[int64]("int64")
+	Register[uint]("uint")
+	Register[uint8]("uint8")
+	Register[uint16]("uint16")
+	Register[uint32]("uint32")
+	Register[uint64]("uint64")
+	Register[uintptr]("uintptr")
+	Register[float32]("float32")
+	Register[float64]("float64")
+}
+
+// Register names type T, and records T with [lift.Register].
+// Register panics if the name or type is already registered otherwise.
+func Register[T any](name string) {
+	lift.Register[T]()
+	t := lift.ReflectType(lift.T[T]())
+
+	registry.Lock()
+	defer registry.Unlock()
+	if prev, ok := registry.byName[name]; ok && prev != t {
+		panic(fmt.Sprintf("codec: name %q already registered for %s", name, prev))
+	}
+	if prev, ok := registry.byType[t]; ok && prev != name {
+		panic(fmt.Sprintf("codec: %s already registered as %q", t, prev))
+	}
+	registry.byName[name] = t
+	registry.byType[t] = name
+}
+
+// Name returns the registered name of the flavor of sym.
+func Name(sym lift.Sym) (string, bool) {
+	if sym == nil {
+		return "", false
+	}
+	registry.RLock()
+	defer registry.RUnlock()
+	name, ok := registry.byType[lift.ReflectType(sym)]
+	return name, ok
+}
+
+// typeOf returns the type registered with a name.
+func typeOf(name string) (reflect.Type, bool) {
+	registry.RLock()
+	defer registry.RUnlock()
+	t, ok := registry.byName[name]
+	return t, ok
+}
+
+// value returns the value wrapped by sym, and its registered name.
+func value(sym lift.Sym) (any, string, error) {
+	if sym == nil {
+		return nil, "", fmt.Errorf("codec: want wrapped value, got nil")
+	}
+	v, ok := lift.ReflectValue(sym)
+	if !ok {
+		return nil, "", fmt.Errorf("codec: want wrapped value, got %s", lift.ReflectType(sym))
+	}
+	name, ok := Name(sym)
+	if !ok {
+		return nil, "", fmt.Errorf("codec: %s: %w", v.Type(), ErrUnknownType)
+	}
+	return v.Interface(), name, nil
+}
+
+// wrap decodes a value of the type registered with a name, wrapping it with its flavor.
+func wrap(name string, decode func(ptr any) error) (lift.Sym, error) {
+	t, ok := typeOf(name)
+	if !ok {
+		return nil, fmt.Errorf("codec: %q: %w", name, ErrUnknownType)
+	}
+	p := reflect.New(t)
+	if err := decode(p.Interface()); err != nil {
+		return nil, fmt.Errorf("codec: %s: %w", name, err)
+	}
+	sym, _ := lift.WrapValue(p.Elem())
+	return sym, nil
+}
+
+// JSON
+
+type envelope struct {
+	Type  string          `json:"type"`
+	Value json.RawMessage `json:"value"`
+}
+
+// Marshal encodes a wrapped value as JSON, {"type": "<name>", "value": ...}.
+// The flavor of sym must be registered.
+func Marshal(sym lift.Sym) ([]byte, error) {
+	v, name, err := value(sym)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("codec: %s: %w", name, err)
+	}
+	return json.Marshal(envelope{name, raw})
+}
+
+// Unmarshal decodes JSON encoded by [Marshal], yielding a value wrapped with the flavor
+// of the named type. Unregistered names are reported with [ErrUnknownType].
+func Unmarshal(data []byte) (lift.Sym, error) {
+	var env envelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		return nil, fmt.Errorf("codec: %w", err)
+	}
+	return wrap(env.Type, func(ptr any) error {
+		if env.Value == nil {
+			return nil
+		}
+		return json.Unmarshal(env.Value, ptr)
+	})
+}
+
+// Any holds a wrapped value, encoded to JSON as by [Marshal].
+// A nil Sym is encoded as null.
+type Any struct {
+	Sym lift.Sym
+}
+
+func (a Any) MarshalJSON() ([]byte, error) {
+	if a.Sym == nil {
+		return []byte("null"), nil
+	}
+	return Marshal(a.Sym)
+}
+
+func (a *Any) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		a.Sym = nil
+		return nil
+	}
+	sym, err := Unmarshal(data)
+	if err != nil {
+		return err
+	}
+	a.Sym = sym
+	return nil
+}
@@ -0,0 +1,148 @@
+package codec_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AndrewHarrisSPU/lift"
+	"github.com/AndrewHarrisSPU/lift/codec"
+)
+
+// Ensure unknown names and types, unwrapped Syms, and conflicting registrations are reported
+func TestJSONErrors(t *testing.T) {
+	type unnamed struct{}
+
+	if _, err := codec.Unmarshal([]byte(`{"type":"nope","value":1}`)); !errors.Is(err, codec.ErrUnknownType) {
+		t.Errorf("missing ErrUnknownType: %v", err)
+	}
+	if _, err := codec.Marshal(lift.Wrap(unnamed{})); !errors.Is(err, codec.ErrUnknownType) {
+		t.Errorf("missing ErrUnknownType: %v", err)
+	}
+	if _, err := codec.Marshal(lift.T[int]()); err == nil {
+		t.Errorf("missing error for unwrapped Sym")
+	}
+	if _, err := codec.Marshal(nil); err == nil {
+		t.Errorf("missing error for nil Sym")
+	}
+	if err := codec.NewEncoder(io.Discard).Encode(nil); err == nil {
+		t.Errorf("missing error for nil Sym")
+	}
+	if _, ok := codec.Name(nil); ok {
+		t.Errorf("unexpected name for nil Sym")
+	}
+	if _, err := codec.Unmarshal([]byte(`{"type":"int","value":"one"}`)); err == nil {
+		t.Errorf("missing error for mistyped value")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("missing panic for conflicting name")
+		}
+	}()
+	codec.Register[unnamed]("calc.digit")
+}
+
+// Ensure null Any fields round trip
+func TestAnyNull(t *testing.T) {
+	var a codec.Any
+	data, err := a.MarshalJSON()
+	if err != nil || string(data) != "null" {
+		t.Fatalf("MarshalJSON: %s, %v", data, err)
+	}
+	a.Sym = lift.Wrap(1)
+	if err := a.UnmarshalJSON(data); err != nil || a.Sym != nil {
+		t.Errorf("UnmarshalJSON: %v, %v", a.Sym, err)
+	}
+}
+
+type celsius float64
+
+type reading struct {
+	Sensor string
+	Temp   celsius
+}
+
+func init() {
+	codec.Register[time.Time]("time.Time")
+	codec.RegisterBinary("test.reading",
+		func(r reading) ([]byte, error) {
+			return []byte(fmt.Sprintf("%s=%g", r.Sensor, r.Temp)), nil
+		},
+		func(data []byte) (r reading, err error) {
+			_, err = fmt.Sscanf(strings.Replace(string(data), "=", " ", 1), "%s %g", &r.Sensor, &r.Temp)
+			return r, err
+		},
+	)
+}
+
+// Ensure values of unknown types are skipped, and registered binary
+// and encoding.BinaryMarshaler payloads round trip
+func TestStreamSkip(t *testing.T) {
+	var buf bytes.Buffer
+
+	// a definition and value from a newer writer, with a type unknown here
+	frame := func(body ...byte) {
+		buf.Write(binary.AppendUvarint(nil, uint64(len(body))))
+		buf.Write(body)
+	}
+	frame(append([]byte{0, 1}, "future.type"...)...)
+	frame(1, 0xde, 0xad)
+
+	now := time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC)
+	enc := codec.NewEncoder(&buf)
+	for _, sym := range []lift.Sym{lift.Wrap(reading{"attic", 21.5}), lift.Wrap(now), lift.Wrap("done")} {
+		if err := enc.Encode(sym); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	dec := codec.NewDecoder(&buf)
+	if _, err := dec.Next(); !errors.Is(err, codec.ErrUnknownType) {
+		t.Fatalf("missing ErrUnknownType: %v", err)
+	}
+	if sym, err := dec.Next(); err != nil || lift.MustUnwrap[reading](sym) != (reading{"attic", 21.5}) {
+		t.Errorf("reading: %v, %v", sym, err)
+	}
+	if sym, err := dec.Next(); err != nil || !lift.MustUnwrap[time.Time](sym).Equal(now) {
+		t.Errorf("time: %v, %v", sym, err)
+	}
+	if sym, err := dec.Next(); err != nil || lift.MustUnwrap[string](sym) != "done" {
+		t.Errorf("string: %v, %v", sym, err)
+	}
+	if _, err := dec.Next(); err != io.EOF {
+		t.Errorf("missing io.EOF: %v", err)
+	}
+}
+
+// Ensure a truncated frame is reported
+func TestStreamTruncated(t *testing.T) {
+	var buf bytes.Buffer
+	codec.NewEncoder(&buf).Encode(lift.Wrap(digit(1)))
+
+	dec := codec.NewDecoder(bytes.NewReader(buf.Bytes()[:buf.Len()-1]))
+	if _, err := dec.Next(); err != io.ErrUnexpectedEOF {
+		t.Errorf("missing io.ErrUnexpectedEOF: %v", err)
+	}
+}
+
+// Ensure frames above the size limit are reported, rather than allocated
+func TestStreamFrameLimit(t *testing.T) {
+	dec := codec.NewDecoder(bytes.NewReader(binary.AppendUvarint(nil, 1<<62)))
+	if _, err := dec.Next(); !errors.Is(err, codec.ErrFrameTooLarge) {
+		t.Errorf("missing ErrFrameTooLarge: %v", err)
+	}
+
+	var buf bytes.Buffer
+	codec.NewEncoder(&buf).Encode(lift.Wrap("a string longer than the limit"))
+	dec = codec.NewDecoder(&buf)
+	dec.SetMaxFrameSize(16)
+	if _, err := dec.Next(); !errors.Is(err, codec.ErrFrameTooLarge) {
+		t.Errorf("missing ErrFrameTooLarge: %v", err)
+	}
+}
@@ -0,0 +1,104 @@
+package codec_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/AndrewHarrisSPU/lift"
+	"github.com/AndrewHarrisSPU/lift/codec"
+)
+
+type digit int
+type op string
+
+func init() {
+	codec.Register[digit]("calc.digit")
+	codec.Register[op]("calc.op")
+}
+
+func Example() {
+	data, _ := codec.Marshal(lift.Wrap(digit(7)))
+	fmt.Println(string(data))
+
+	sym, _ := codec.Unmarshal(data)
+	d, ok := lift.Unwrap[digit](sym)
+	fmt.Println(d, ok)
+	// Output:
+	// {"type":"calc.digit","value":7}
+	// 7 true
+}
+
+// Any fields encode polymorphic values. Decoded values are flavored for dispatch.
+func ExampleAny() {
+	type message struct {
+		Seq   int
+		Input codec.Any
+	}
+
+	var msgs []message
+	for i, sym := range []lift.Sym{lift.Wrap(digit(1)), lift.Wrap(op("+")), lift.Wrap(digit(2))} {
+		msgs = append(msgs, message{Seq: i, Input: codec.Any{Sym: sym}})
+	}
+	data, _ := json.Marshal(msgs)
+	fmt.Println(string(data))
+
+	handlers := lift.NewMap[func(lift.Sym) string]()
+	handlers.Store(
+		lift.Def[digit](func(sym lift.Sym) string {
+			return fmt.Sprint("digit ", lift.MustUnwrap[digit](sym))
+		}),
+		lift.Def[op](func(sym lift.Sym) string {
+			return fmt.Sprint("op ", lift.MustUnwrap[op](sym))
+		}),
+	)
+
+	var decoded []message
+	json.Unmarshal(data, &decoded)
+	for _, msg := range decoded {
+		handle, _ := lift.LoadSym(handlers, msg.Input.Sym)
+		fmt.Println(msg.Seq, handle(msg.Input.Sym))
+	}
+	// Output:
+	// [{"Seq":0,"Input":{"type":"calc.digit","value":1}},{"Seq":1,"Input":{"type":"calc.op","value":"+"}},{"Seq":2,"Input":{"type":"calc.digit","value":2}}]
+	// 0 digit 1
+	// 1 op +
+	// 2 digit 2
+}
+
+// Values of varied flavors are streamed in frames, and dispatched as they are decoded.
+func ExampleDecoder_Next() {
+	type point struct{ X, Y int }
+	codec.Register[point]("geo.point")
+
+	var buf bytes.Buffer
+	enc := codec.NewEncoder(&buf)
+	enc.Encode(lift.Wrap(digit(4)))
+	enc.Encode(lift.Wrap(point{1, 2}))
+	enc.Encode(lift.Wrap(op("*")))
+	enc.Encode(lift.Wrap(digit(2)))
+
+	handlers := lift.NewMap[string]()
+	handlers.Store(
+		lift.Def[digit]("digit"),
+		lift.Def[op]("op"),
+		lift.Def[point]("point"),
+	)
+
+	dec := codec.NewDecoder(&buf)
+	for {
+		sym, err := dec.Next()
+		if err == io.EOF {
+			break
+		}
+		kind, _ := lift.LoadSym(handlers, sym)
+		v, _ := lift.ReflectValue(sym)
+		fmt.Println(kind, v)
+	}
+	// Output:
+	// digit 4
+	// point {1 2}
+	// op *
+	// digit 2
+}
@@ -0,0 +1,218 @@
+package codec
+
+import (
+	"bufio"
+	"bytes"
+	"encoding"
+	"encoding/binary"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+
+	"github.com/AndrewHarrisSPU/lift"
+)
+
+// STREAMS
+
+// A stream is a sequence of frames. Each frame is a uvarint length, followed by a body:
+// a uvarint tag, then a payload. Tags are assigned by an [Encoder] in order of first use.
+// The first frame with a tag is a definition, with tag 0 and a payload of the assigned tag,
+// as a uvarint, followed by the type name. Other frames hold the encoded value of a type.
+const defineTag = 0
+
+// binaryFuncs encode values of a type registered with [RegisterBinary].
+type binaryFuncs struct {
+	marshal   func(reflect.Value) ([]byte, error)
+	unmarshal func([]byte, reflect.Value) error
+}
+
+// RegisterBinary names type T as in [Register], with functions encoding values of type T
+// in a stream.
+func RegisterBinary[T any](name string, marshal func(T) ([]byte, error), unmarshal func([]byte) (T, error)) {
+	Register[T](name)
+
+	registry.Lock()
+	defer registry.Unlock()
+	registry.binary[lift.ReflectType(lift.T[T]())] = binaryFuncs{
+		marshal: func(v reflect.Value) ([]byte, error) {
+			return marshal(v.Interface().(T))
+		},
+		unmarshal: func(data []byte, v reflect.Value) error {
+			t, err := unmarshal(data)
+			if err == nil {
+				v.Set(reflect.ValueOf(&t).Elem())
+			}
+			return err
+		},
+	}
+}
+
+var (
+	binaryMarshaler   = reflect.TypeOf((*encoding.BinaryMarshaler)(nil)).Elem()
+	binaryUnmarshaler = reflect.TypeOf((*encoding.BinaryUnmarshaler)(nil)).Elem()
+)
+
+// payloadFuncs returns the functions encoding values of a type in a stream. Functions registered
+// with [RegisterBinary] are preferred, then [encoding.BinaryMarshaler] and
+// [encoding.BinaryUnmarshaler] implementations, and then [encoding/gob].
+func payloadFuncs(t reflect.Type) binaryFuncs {
+	registry.RLock()
+	fs, ok := registry.binary[t]
+	registry.RUnlock()
+	if ok {
+		return fs
+	}
+
+	if t.Implements(binaryMarshaler) && reflect.PointerTo(t).Implements(binaryUnmarshaler) {
+		return binaryFuncs{
+			marshal: func(v reflect.Value) ([]byte, error) {
+				return v.Interface().(encoding.BinaryMarshaler).MarshalBinary()
+			},
+			unmarshal: func(data []byte, v reflect.Value) error {
+				return v.Addr().Interface().(encoding.BinaryUnmarshaler).UnmarshalBinary(data)
+			},
+		}
+	}
+
+	return binaryFuncs{
+		marshal: func(v reflect.Value) ([]byte, error) {
+			var buf bytes.Buffer
+			err := gob.NewEncoder(&buf).EncodeValue(v)
+			return buf.Bytes(), err
+		},
+		unmarshal: func(data []byte, v reflect.Value) error {
+			return gob.NewDecoder(bytes.NewReader(data)).DecodeValue(v.Addr())
+		},
+	}
+}
+
+// An Encoder writes wrapped values to a stream.
+type Encoder struct {
+	w    io.Writer
+	tags map[reflect.Type]uint64
+	buf  []byte
+}
+
+// NewEncoder returns an [Encoder] writing to w.
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{w: w, tags: make(map[reflect.Type]uint64)}
+}
+
+// Encode writes a wrapped value to the stream. The flavor of sym must be registered.
+// Each frame is written with a single call to Write.
+func (enc *Encoder) Encode(sym lift.Sym) error {
+	v, name, err := value(sym)
+	if err != nil {
+		return err
+	}
+	rv := reflect.ValueOf(v)
+
+	payload, err := payloadFuncs(rv.Type()).marshal(rv)
+	if err != nil {
+		return fmt.Errorf("codec: %s: %w", name, err)
+	}
+
+	tag, ok := enc.tags[rv.Type()]
+	if !ok {
+		tag = uint64(len(enc.tags) + 1)
+		def := binary.AppendUvarint(nil, tag)
+		if err := enc.frame(defineTag, append(def, name...)); err != nil {
+			return err
+		}
+		enc.tags[rv.Type()] = tag
+	}
+	return enc.frame(tag, payload)
+}
+
+// frame writes a frame with a tag and payload.
+func (enc *Encoder) frame(tag uint64, payload []byte) error {
+	tagLen := len(binary.AppendUvarint(nil, tag))
+	enc.buf = binary.AppendUvarint(enc.buf[:0], uint64(tagLen+len(payload)))
+	enc.buf = binary.AppendUvarint(enc.buf, tag)
+	enc.buf = append(enc.buf, payload...)
+	_, err := enc.w.Write(enc.buf)
+	return err
+}
+
+// DefaultMaxFrameSize is the default limit of the size of a frame read by a [Decoder].
+const DefaultMaxFrameSize = 16 << 20
+
+// ErrFrameTooLarge is reported when a frame exceeds the limit of a [Decoder].
+var ErrFrameTooLarge = errors.New("frame too large")
+
+// A Decoder reads wrapped values from a stream.
+type Decoder struct {
+	r     *bufio.Reader
+	names map[uint64]string
+	max   uint64
+}
+
+// NewDecoder returns a [Decoder] reading from r.
+// Frames are limited to [DefaultMaxFrameSize] bytes.
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{r: bufio.NewReader(r), names: make(map[uint64]string), max: DefaultMaxFrameSize}
+}
+
+// SetMaxFrameSize limits the size of frames read by a [Decoder].
+// Larger frames are reported with [ErrFrameTooLarge], leaving the stream unreadable.
+func (dec *Decoder) SetMaxFrameSize(n int) {
+	dec.max = uint64(n)
+}
+
+// Next reads the next value from the stream, wrapped with the flavor of its registered type.
+// At the end of the stream, Next returns [io.EOF].
+//
+// A value of an unregistered type is skipped, and reported with [ErrUnknownType].
+// Following such a failure, or a failure to decode a payload, values may still be read.
+// Malformed or truncated frames leave the stream unreadable.
+func (dec *Decoder) Next() (lift.Sym, error) {
+	for {
+		tag, payload, err := dec.frame()
+		if err != nil {
+			return nil, err
+		}
+		if tag != defineTag {
+			name, ok := dec.names[tag]
+			if !ok {
+				return nil, fmt.Errorf("codec: tag %d: %w", tag, ErrUnknownType)
+			}
+			return wrap(name, func(ptr any) error {
+				v := reflect.ValueOf(ptr).Elem()
+				return payloadFuncs(v.Type()).unmarshal(payload, v)
+			})
+		}
+
+		def := bytes.NewReader(payload)
+		defTag, err := binary.ReadUvarint(def)
+		if err != nil || defTag == defineTag {
+			return nil, errors.New("codec: malformed definition")
+		}
+		dec.names[defTag] = string(payload[len(payload)-def.Len():])
+	}
+}
+
+// frame reads a frame, returning its tag and payload.
+func (dec *Decoder) frame() (uint64, []byte, error) {
+	n, err := binary.ReadUvarint(dec.r)
+	if err != nil {
+		return 0, nil, err
+	}
+	if n > dec.max {
+		return 0, nil, fmt.Errorf("codec: %d bytes: %w", n, ErrFrameTooLarge)
+	}
+	body := make([]byte, n)
+	if _, err := io.ReadFull(dec.r, body); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return 0, nil, err
+	}
+
+	tag, size := binary.Uvarint(body)
+	if size <= 0 {
+		return 0, nil, errors.New("codec: malformed frame")
+	}
+	return tag, body[size:], nil
+}
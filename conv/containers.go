@@ -0,0 +1,222 @@
+package conv
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/AndrewHarrisSPU/lift"
+)
+
+// CONTAINERS
+
+// A Policy determines how conversions of container elements fail.
+type Policy int
+
+const (
+	// FailFast stops converting at the first failed element.
+	FailFast Policy = iota
+	// Aggregate converts every element, collecting all failures.
+	Aggregate
+)
+
+// WithPolicy sets the [Policy] used by [ToSlice], [ToMap], [Fill], and [ToChan].
+// The default is [FailFast].
+func WithPolicy(p Policy) Option {
+	return option(func(cfg *config) {
+		cfg.policy = p
+	})
+}
+
+// An ElemError reports a failed conversion of a container element.
+type ElemError struct {
+	// Key is the index of a slice, array, or channel element, the key of a map element,
+	// or the path of a struct field.
+	Key any
+	Err error
+}
+
+func (e *ElemError) Error() string {
+	return fmt.Sprintf("[%v]: %v", e.Key, e.Err)
+}
+
+func (e *ElemError) Unwrap() error {
+	return e.Err
+}
+
+// A BatchError collects failed conversions of container elements.
+// Under the [FailFast] policy, a BatchError holds a single failure.
+type BatchError struct {
+	Errs []*ElemError
+}
+
+func (e *BatchError) Error() string {
+	msgs := make([]string, len(e.Errs))
+	for i, err := range e.Errs {
+		msgs[i] = err.Error()
+	}
+	return fmt.Sprintf("conv: failed elements: %s", strings.Join(msgs, "; "))
+}
+
+func (e *BatchError) Unwrap() []error {
+	errs := make([]error, len(e.Errs))
+	for i, err := range e.Errs {
+		errs[i] = err
+	}
+	return errs
+}
+
+// ToSlice converts each element of a slice to the provided DST type.
+func ToSlice[DST any, SRC any](cv Converter, src []SRC) ([]DST, error) {
+	dst := make([]DST, len(src))
+	if err := Fill(cv, dst, src); err != nil {
+		return nil, err
+	}
+	return dst, nil
+}
+
+// Fill converts each element of src to the corresponding element of dst.
+// To convert arrays, slice them: Fill(cv, dst[:], src[:]).
+func Fill[DST any, SRC any](cv Converter, dst []DST, src []SRC) error {
+	if len(dst) != len(src) {
+		return fmt.Errorf("conv: Fill: %d destination elements, %d source elements", len(dst), len(src))
+	}
+	convFunc, err := elemFunc[DST, SRC](cv)
+	if err != nil {
+		return err
+	}
+
+	var batch BatchError
+	for i, s := range src {
+		d, err := convFunc(s)
+		if err != nil {
+			batch.Errs = append(batch.Errs, &ElemError{Key: i, Err: err})
+			if cv.cfg.policy == FailFast {
+				break
+			}
+			continue
+		}
+		dst[i] = d
+	}
+	if len(batch.Errs) > 0 {
+		return &batch
+	}
+	return nil
+}
+
+// ErrDuplicateKey is the cause of an [ElemError] when [ToMap] converts several source keys
+// to the same destination key.
+var ErrDuplicateKey = errors.New("duplicate destination key")
+
+// ToMap converts each key and value of a map to the provided DK and DV types.
+// Under the [Aggregate] policy, failures are ordered by formatted key.
+// When source keys collide, the first by formatted key is converted, and others fail
+// with [ErrDuplicateKey].
+func ToMap[DK comparable, DV any, SK comparable, SV any](cv Converter, src map[SK]SV) (map[DK]DV, error) {
+	keyFunc, err := elemFunc[DK, SK](cv)
+	if err != nil {
+		return nil, err
+	}
+	valFunc, err := elemFunc[DV, SV](cv)
+	if err != nil {
+		return nil, err
+	}
+
+	var batch BatchError
+	dst := make(map[DK]DV, len(src))
+	from := make(map[DK]SK, len(src))
+	for sk, sv := range src {
+		dk, err := keyFunc(sk)
+		if err == nil {
+			var dv DV
+			if dv, err = valFunc(sv); err == nil {
+				prev, dup := from[dk]
+				if !dup {
+					dst[dk], from[dk] = dv, sk
+					continue
+				}
+				// of colliding source keys, the first by formatted key keeps the destination key
+				if fmt.Sprint(sk) < fmt.Sprint(prev) {
+					dst[dk], from[dk] = dv, sk
+					sk = prev
+				}
+				err = fmt.Errorf("%w: %v", ErrDuplicateKey, dk)
+			}
+		}
+
+		batch.Errs = append(batch.Errs, &ElemError{Key: sk, Err: err})
+		if cv.cfg.policy == FailFast {
+			break
+		}
+	}
+	if len(batch.Errs) > 0 {
+		sort.Slice(batch.Errs, func(i, j int) bool {
+			return fmt.Sprint(batch.Errs[i].Key) < fmt.Sprint(batch.Errs[j].Key)
+		})
+		return nil, &batch
+	}
+	return dst, nil
+}
+
+// A Result is a converted channel element, or the failure to convert it.
+type Result[T any] struct {
+	Value T
+	Err   error
+}
+
+// ToChan converts each element received from a channel to the provided DST type.
+// The returned channel is closed after src is closed. Failures are sent as a
+// [Result] holding an [*ElemError], keyed by the count of preceding elements.
+// Under the [FailFast] policy, elements following a failure are received but discarded.
+func ToChan[DST any, SRC any](cv Converter, src <-chan SRC) <-chan Result[DST] {
+	out := make(chan Result[DST])
+	convFunc, err := elemFunc[DST, SRC](cv)
+
+	go func() {
+		defer close(out)
+		if err != nil {
+			out <- Result[DST]{Err: err}
+		}
+
+		i := 0
+		for s := range src {
+			if err != nil {
+				continue
+			}
+			var d DST
+			if d, err = convFunc(s); err != nil {
+				err = &ElemError{Key: i, Err: err}
+				out <- Result[DST]{Err: err}
+				if cv.cfg.policy == Aggregate {
+					err = nil
+				}
+			} else {
+				out <- Result[DST]{Value: d}
+			}
+			i++
+		}
+	}()
+	return out
+}
+
+// elemFunc returns a function converting elements, or an error if no conversion is found.
+// The conversion is looked up once, and failures are reported as in [To].
+func elemFunc[DST any, SRC any](cv Converter) (func(SRC) (DST, error), error) {
+	if lift.T[SRC]() == lift.T[DST]() && !cv.intercepts() {
+		return func(src SRC) (DST, error) {
+			return any(src).(DST), nil
+		}, nil
+	}
+
+	convFunc, ok := Lookup[SRC, DST](cv)
+	if !ok {
+		return nil, cv.notFound(lift.T[SRC](), lift.T[DST]())
+	}
+	return func(src SRC) (dst DST, err error) {
+		if dst, err = convFunc(src); err != nil {
+			return dst, &Error{Src: lift.T[SRC](), Dst: lift.T[DST](), Err: err}
+		}
+		return dst, nil
+	}, nil
+}
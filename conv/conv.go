@@ -1,69 +1,272 @@
-// The conv package provides a [Coverter], which indexes conversion functions.
+// The conv package provides a [Converter], which indexes conversion functions.
+//
+// When no conversion function from a source type to a destination type is defined,
+// a [Converter] searches for a chain of defined conversions, preferring the chain
+// of least total cost. Pointer sources are dereferenced, and pointer destinations
+// are produced, when a conversion between the underlying types is found.
+//
+// Conversions to an interface type are found in order of priority:
+//  1. A defined conversion to the interface type, or a chain of defined conversions
+//  2. Type assertion, when the source implements the interface, as in [lift.UnwrapAs].
+//     A source of interface type is asserted by its dynamic type.
+//  3. The cheapest chain of defined conversions to a type implementing the interface
 package conv
 
 import (
+	"context"
 	"fmt"
+	"reflect"
 
 	"github.com/AndrewHarrisSPU/lift"
 )
 
-// A Converter indexes conversion functions
+// A Converter indexes conversion functions.
+// Copies of a Converter share definitions; see [Converter.Clone] for an independent copy.
+// A Converter is safe for concurrent use.
 type Converter struct {
-	defs			lift.Map[Entry]	
+	edges  lift.Map[lift.Map[Entry]]
+	cfg    *config
+	state  *state
+	parent *Converter
 }
 
-// An entry is a wrapped conversion function
-type Entry lift.Sym
+// config holds [Converter] settings, configured by an [Option].
+type config struct {
+	policy    Policy
+	nils      NilPolicy
+	fallback  Fallback
+	encodings bool
+	structs   StructMode
 
-func NewConverter(defs ...Entry) Converter {
-	cv := Converter{
-		defs:		lift.NewMap[Entry](),
+	interceptors     []Interceptor
+	pairInterceptors map[[2]reflect.Type][]Interceptor
+}
+
+// An Entry is a wrapped conversion function
+type Entry struct {
+	src, dst lift.Sym
+	fn       lift.Sym
+	call     symFunc
+	cost     int
+	direct   bool
+}
+
+// NewConverter returns an initialized [Converter], configured by any provided options.
+// An [Entry] is an [Option], defining a conversion.
+//
+// NewConverter formerly accepted only entries, as NewConverter(defs ...Entry). This is a
+// breaking change for calls spreading a slice of entries, NewConverter(defs...), which
+// now wrap the slice as [Entries]: NewConverter(Entries(defs)).
+func NewConverter(opts ...Option) Converter {
+	cv := newConverter(new(config), nil)
+	for _, opt := range opts {
+		opt.apply(cv)
 	}
-	cv.Store(defs...)
 	return cv
 }
 
+// An Option configures a [Converter].
+type Option interface {
+	apply(Converter)
+}
+
+type option func(*config)
+
+func (opt option) apply(cv Converter) {
+	opt(cv.cfg)
+}
+
+func (e Entry) apply(cv Converter) {
+	cv.Store(e)
+}
+
 // Def wraps a conversion function, yielding an [Entry]
-func Def[SRC any, DST any]( convFunc func( SRC )( DST, error )) Entry {
-	return Entry( lift.Wrap(convFunc))
+func Def[SRC any, DST any](convFunc func(SRC) (DST, error)) Entry {
+	return def(convFunc, func(_ context.Context, src SRC) (DST, error) {
+		return convFunc(src)
+	})
+}
+
+// DefInfallible wraps a conversion function that can't fail, yielding an [Entry]
+func DefInfallible[SRC any, DST any](convFunc func(SRC) DST) Entry {
+	return Def(func(src SRC) (DST, error) {
+		return convFunc(src), nil
+	})
+}
+
+// DefOK wraps a conversion function reporting success with a boolean, yielding an [Entry].
+// Failures are reported with [ErrNotOK].
+func DefOK[SRC any, DST any](convFunc func(SRC) (DST, bool)) Entry {
+	return Def(func(src SRC) (DST, error) {
+		dst, ok := convFunc(src)
+		if !ok {
+			return dst, ErrNotOK
+		}
+		return dst, nil
+	})
+}
+
+// DefCtx wraps a conversion function observing a [context.Context], yielding an [Entry].
+// The context is provided by [ToCtx]; elsewhere, it is [context.Background].
+func DefCtx[SRC any, DST any](convFunc func(context.Context, SRC) (DST, error)) Entry {
+	return def(func(src SRC) (DST, error) {
+		return convFunc(context.Background(), src)
+	}, convFunc)
+}
+
+// def builds an [Entry]. The fn conversion function is found by [Lookup].
+func def[SRC any, DST any](fn func(SRC) (DST, error), ctxFn func(context.Context, SRC) (DST, error)) Entry {
+	register[SRC]()
+	register[DST]()
+	return Entry{
+		src: lift.T[SRC](),
+		dst: lift.T[DST](),
+		fn:  lift.Wrap(fn),
+		call: func(ctx context.Context, sym lift.Sym) (lift.Sym, error) {
+			dst, err := ctxFn(ctx, lift.MustUnwrap[SRC](sym))
+			return lift.Wrap(dst), err
+		},
+		cost: 1,
+	}
+}
+
+// Cost sets the cost of an [Entry], weighing chains of conversions. The default cost is 1.
+// Costs must not be negative; Cost panics if cost < 0.
+func (e Entry) Cost(cost int) Entry {
+	if cost < 0 {
+		panic(fmt.Sprintf("conv: negative cost %d", cost))
+	}
+	e.cost = cost
+	return e
+}
+
+// Direct marks an [Entry] as a conversion used only on its own, never chained with other
+// conversions. Direct entries suit conversions to and from a hub type, like string,
+// that would otherwise connect nearly every pair of types.
+func (e Entry) Direct() Entry {
+	e.direct = true
+	return e
 }
 
 // Lookup returns a conversion function from source to destination type, if found.
-func Lookup[SRC any, DST any]( cv Converter) (func(SRC)(DST,error), bool ){
-	sig := lift.T[func(SRC)(DST, error)]()
-	if convFunc, ok := lift.LoadSym( cv.defs, sig ); ok {
-		return lift.MustUnwrap[func(SRC)(DST,error)](convFunc), true
+// The conversion function may chain several defined conversions.
+func Lookup[SRC any, DST any](cv Converter) (func(SRC) (DST, error), bool) {
+	if path, ok := cv.path(lift.T[SRC](), lift.T[DST]()); ok && len(path) == 1 && !cv.intercepts() {
+		return lift.MustUnwrap[func(SRC) (DST, error)](path[0].fn), true
+	}
+
+	register[SRC]()
+	register[DST]()
+
+	convFunc, ok := cv.resolve(lift.T[SRC](), lift.T[DST]())
+	if !ok {
+		return nil, false
 	}
-	return nil, false
+	convFunc = cv.intercept(lift.T[SRC](), lift.T[DST](), convFunc)
+	return func(src SRC) (dst DST, err error) {
+		sym, err := convFunc(context.Background(), lift.Wrap(src))
+		if err != nil {
+			return dst, err
+		}
+		return lift.MustUnwrap[DST](sym), nil
+	}, true
 }
 
 // To converts the src argument to the provided DST type.
 // Conversion may fail if a conversion function isn't found,
 // or it may fail if a particular value fails to convert.
-func To[DST any, SRC any](cv Converter, src SRC) (dst DST, err error ){
+// Failures are reported as an [*Error].
+func To[DST any, SRC any](cv Converter, src SRC) (dst DST, err error) {
 	// If SRC == DST, return src
-	if lift.T[SRC]() == lift.T[DST]() {
-		return any( src ).(DST), nil
+	if lift.T[SRC]() == lift.T[DST]() && !cv.intercepts() {
+		return any(src).(DST), nil
 	}
 
 	if convFunc, ok := Lookup[SRC, DST](cv); ok {
-		return convFunc( src )
+		if dst, err = convFunc(src); err != nil {
+			return dst, &Error{Src: lift.T[SRC](), Dst: lift.T[DST](), Err: err}
+		}
+		return dst, nil
+	}
+	return dst, cv.notFound(lift.T[SRC](), lift.T[DST]())
+}
+
+// ToCtx resembles [To], observing a [context.Context]. The context is checked before
+// each conversion in a chain, and is provided to conversion functions defined with [DefCtx].
+func ToCtx[DST any, SRC any](ctx context.Context, cv Converter, src SRC) (dst DST, err error) {
+	if err := ctx.Err(); err != nil {
+		return dst, &Error{Src: lift.T[SRC](), Dst: lift.T[DST](), Err: err}
+	}
+
+	register[SRC]()
+	register[DST]()
+	convFunc, ok := cv.resolve(lift.T[SRC](), lift.T[DST]())
+	if !ok {
+		return dst, cv.notFound(lift.T[SRC](), lift.T[DST]())
 	}
-	return dst, fmt.Errorf( "Conversion not found: %T->%T", src, dst )
+	convFunc = cv.intercept(lift.T[SRC](), lift.T[DST](), convFunc)
+
+	sym, err := convFunc(ctx, lift.Wrap(src))
+	if err != nil {
+		return dst, &Error{Src: lift.T[SRC](), Dst: lift.T[DST](), Err: err}
+	}
+	return lift.MustUnwrap[DST](sym), nil
+}
+
+// ToSym converts a wrapped value to the flavor of dst, yielding a wrapped value.
+// The src argument must be produced by [lift.Wrap]; the dst argument needn't be, but must not be nil.
+// Unlike [To], the source type needn't be known until runtime.
+func ToSym(cv Converter, src lift.Sym, dst lift.Sym) (lift.Sym, error) {
+	if dst == nil {
+		return nil, &Error{Src: flavor(src), Err: ErrNoDst}
+	}
+	if _, ok := lift.ReflectValue(src); !ok {
+		return nil, &Error{Src: flavor(src), Dst: lift.Flavor(dst), Err: ErrUnwrapped}
+	}
+
+	if convFunc, ok := cv.resolve(src, dst); ok {
+		convFunc = cv.intercept(src, dst, convFunc)
+		sym, err := convFunc(context.Background(), src)
+		if err != nil {
+			return nil, &Error{Src: lift.Flavor(src), Dst: lift.Flavor(dst), Err: err}
+		}
+		return sym, nil
+	}
+	return nil, cv.notFound(src, dst)
+}
+
+// ToFrom converts a wrapped value to the provided DST type, as in [ToSym].
+func ToFrom[DST any](cv Converter, src lift.Sym) (dst DST, err error) {
+	sym, err := ToSym(cv, src, lift.T[DST]())
+	if err != nil {
+		return dst, err
+	}
+	return lift.MustUnwrap[DST](sym), nil
 }
 
 // Store defines a conversion in the [Converter].
+// Store panics if the Converter is frozen.
 func (cv Converter) Store(defs ...Entry) {
+	cv.lock("Store")
+	defer cv.state.mu.Unlock()
+
 	for _, def := range defs {
-		cv.defs.Store(
-			lift.DefSym( lift.Sym( def ), def ),
-		)
+		cv.store(def)
 	}
+	cv.invalidate()
 }
 
 // Delete removes a defined conversion from the [Converter].
+// Conversions defined by a parent, as in [Converter.Child], aren't removed.
+// Delete panics if the Converter is frozen.
 func (cv Converter) Delete(keys ...Entry) {
+	cv.lock("Delete")
+	defer cv.state.mu.Unlock()
+
 	for _, key := range keys {
-		cv.defs.Delete( key )
+		if dsts, ok := lift.LoadSym(cv.edges, key.src); ok {
+			dsts.Delete(key.dst)
+		}
 	}
-}
\ No newline at end of file
+	cv.invalidate()
+}
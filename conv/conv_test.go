@@ -0,0 +1,784 @@
+package conv_test
+
+import (
+	"context"
+	"database/sql"
+	"encoding"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/AndrewHarrisSPU/lift"
+	"github.com/AndrewHarrisSPU/lift/conv"
+)
+
+// Ensure cached paths are invalidated by Store and Delete
+func TestPathCache(t *testing.T) {
+	type a int
+	type b int
+	type c int
+
+	a2b := conv.Def(func(x a) (b, error) { return b(x), nil })
+	b2c := conv.Def(func(x b) (c, error) { return c(x), nil })
+
+	cv := conv.NewConverter(a2b)
+	if _, ok := conv.Path[a, c](cv); ok {
+		t.Errorf("unexpected path")
+	}
+
+	cv.Store(b2c)
+	if path, ok := conv.Path[a, c](cv); !ok || len(path) != 3 {
+		t.Errorf("missing path after Store: %v", path)
+	}
+
+	cv.Delete(a2b)
+	if _, err := conv.To[c](cv, a(1)); err == nil {
+		t.Errorf("stale path after Delete")
+	}
+}
+
+// Ensure FailFast channel conversion discards elements after a failure
+func TestToChanFailFast(t *testing.T) {
+	cv := conv.NewConverter(
+		conv.Def(strconv.Atoi),
+	)
+
+	src := make(chan string, 4)
+	for _, s := range []string{"1", "x", "3", "4"} {
+		src <- s
+	}
+	close(src)
+
+	var values, errs int
+	for res := range conv.ToChan[int](cv, src) {
+		if res.Err != nil {
+			errs++
+		} else {
+			values++
+		}
+	}
+	if values != 1 || errs != 1 {
+		t.Errorf("got %d values, %d errors", values, errs)
+	}
+}
+
+// Ensure aggregated map failures report every key, in order
+func TestToMapAggregate(t *testing.T) {
+	cv := conv.NewConverter(
+		conv.Def(strconv.Atoi),
+		conv.WithPolicy(conv.Aggregate),
+	)
+
+	_, err := conv.ToMap[int, int](cv, map[string]string{"a": "1", "b": "x", "c": "y"})
+	batch, ok := err.(*conv.BatchError)
+	if !ok || len(batch.Errs) != 3 || batch.Errs[0].Key != "a" {
+		t.Errorf("got %v", err)
+	}
+
+	if _, err := conv.ToSlice[bool](cv, []string{}); !errors.Is(err, conv.ErrNotFound) {
+		t.Errorf("missing conversion: got %v", err)
+	}
+}
+
+// Ensure fallback conversions avoid surprising or panicking built-in conversions
+func TestFallbackEdges(t *testing.T) {
+	cv := conv.NewConverter(
+		conv.WithFallback(conv.StrictConvertible),
+	)
+
+	if _, err := conv.To[string](cv, 65); !errors.Is(err, conv.ErrNotFound) {
+		t.Errorf("int->string: got %v", err)
+	}
+	if _, err := conv.To[[4]byte](cv, []byte{1, 2}); err == nil {
+		t.Errorf("short slice->array: missing error")
+	}
+	if n, err := conv.To[uint8](cv, -1); !errors.Is(err, conv.ErrLossy) {
+		t.Errorf("negative int->uint8: got %v, %v", n, err)
+	}
+	if n, err := conv.To[uint64](cv, -1); !errors.Is(err, conv.ErrLossy) {
+		t.Errorf("negative int->uint64: got %v, %v", n, err)
+	}
+	if n, err := conv.To[uint8](cv, int8(-1)); !errors.Is(err, conv.ErrLossy) {
+		t.Errorf("negative int8->uint8: got %v, %v", n, err)
+	}
+	if n, err := conv.To[int64](cv, uint64(math.MaxUint64)); !errors.Is(err, conv.ErrLossy) {
+		t.Errorf("large uint64->int64: got %v, %v", n, err)
+	}
+	if n, err := conv.To[uint](cv, -1.0); !errors.Is(err, conv.ErrLossy) {
+		t.Errorf("negative float64->uint: got %v, %v", n, err)
+	}
+	if n, err := conv.To[int8](cv, uint8(127)); n != 127 || err != nil {
+		t.Errorf("uint8->int8: got %v, %v", n, err)
+	}
+	if f, err := conv.To[float32](cv, math.NaN()); err != nil || !math.IsNaN(float64(f)) {
+		t.Errorf("NaN: got %v, %v", f, err)
+	}
+
+	// fallback is disabled by default
+	if _, err := conv.To[int64](conv.NewConverter(), 1); err == nil {
+		t.Errorf("unexpected fallback")
+	}
+}
+
+// Ensure encoding interfaces are preferred in order, and nil pointers don't panic
+func TestEncodings(t *testing.T) {
+	cv := conv.NewConverter(
+		conv.WithEncodings(),
+	)
+	when := time.Date(2009, 11, 10, 23, 0, 0, 0, time.UTC)
+
+	// TextMarshaler is preferred to Stringer
+	if s, _ := conv.To[string](cv, when); s != "2009-11-10T23:00:00Z" {
+		t.Errorf("marshal: got %s", s)
+	}
+
+	// BinaryUnmarshaler is preferred for []byte sources
+	bin, _ := when.MarshalBinary()
+	if got, err := conv.To[time.Time](cv, bin); err != nil || !got.Equal(when) {
+		t.Errorf("binary: got %v, %v", got, err)
+	}
+
+	if _, err := conv.To[net.IP](cv, "not an address"); err == nil {
+		t.Errorf("unmarshal: missing error")
+	}
+
+	// interface destinations aren't unmarshaled into
+	if _, err := conv.To[encoding.TextUnmarshaler](cv, "x"); err == nil {
+		t.Errorf("interface: missing error")
+	}
+	if _, err := conv.To[encoding.BinaryUnmarshaler](cv, []byte("x")); err == nil {
+		t.Errorf("interface: missing error")
+	}
+
+	var ip *net.IP
+	if _, err := conv.To[string](cv, ip); !errors.Is(err, conv.ErrNil) {
+		t.Errorf("nil source: got %v", err)
+	}
+
+	// encodings are opt-in
+	if _, err := conv.To[string](conv.NewConverter(), when); err == nil {
+		t.Errorf("unexpected encoding")
+	}
+}
+
+// Ensure priority of conversions to interface types
+func TestInterfacePriority(t *testing.T) {
+	type named string
+
+	// a defined conversion to the interface wins over assertion
+	cv := conv.NewConverter(
+		conv.Def(func(n named) (fmt.Stringer, error) {
+			return time.Duration(len(n)), nil
+		}),
+	)
+	if s, _ := conv.To[fmt.Stringer](cv, named("abc")); s.String() != "3ns" {
+		t.Errorf("defined: got %v", s)
+	}
+
+	// assertion of an interface-typed source uses the dynamic type
+	if s, err := conv.To[fmt.Stringer](cv, any(time.Second)); err != nil || s.String() != "1s" {
+		t.Errorf("dynamic: got %v, %v", s, err)
+	}
+	if _, err := conv.To[fmt.Stringer](cv, any(0)); err == nil {
+		t.Errorf("dynamic: missing error")
+	}
+	if s, err := conv.To[fmt.Stringer](cv, any(nil)); err != nil || s != nil {
+		t.Errorf("nil: got %v, %v", s, err)
+	}
+}
+
+// Ensure the context is checked between chained conversions
+func TestToCtxChain(t *testing.T) {
+	type a int
+	type b int
+	type c int
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cv := conv.NewConverter(
+		conv.DefInfallible(func(x a) b {
+			cancel()
+			return b(x)
+		}),
+		conv.DefInfallible(func(x b) c {
+			t.Errorf("conversion after cancellation")
+			return c(x)
+		}),
+	)
+
+	if _, err := conv.ToCtx[c](ctx, cv, a(1)); !errors.Is(err, context.Canceled) {
+		t.Errorf("got %v", err)
+	}
+}
+
+// Ensure reachability terminates on cycles and excludes the queried type,
+// and Require considers conversions enabled by options
+func TestGraphReach(t *testing.T) {
+	cv := conv.NewConverter(
+		conv.Def(strconv.Atoi),
+		conv.DefInfallible(strconv.Itoa),
+		conv.WithFallback(conv.Convertible),
+	)
+
+	if from := cv.From(lift.T[int]()); len(from) != 1 || lift.ReflectType(from[0]).String() != "string" {
+		t.Errorf("From: %v", from)
+	}
+	if to := cv.To(lift.T[bool]()); len(to) != 0 {
+		t.Errorf("To: %v", to)
+	}
+	if err := cv.Require(conv.Pair{Src: lift.T[int](), Dst: lift.T[float64]()}); err != nil {
+		t.Errorf("Require: %v", err)
+	}
+}
+
+// Ensure interceptors see one-step lookups, identity conversions, and container elements,
+// with global interceptors applied before pair interceptors
+func TestInterceptorOrder(t *testing.T) {
+	var seen []string
+	tag := func(name string) conv.Interceptor {
+		return func(p conv.Pair, next conv.Handler) conv.Handler {
+			return func(ctx context.Context, src lift.Sym) (lift.Sym, error) {
+				seen = append(seen, name+" "+p.String())
+				return next(ctx, src)
+			}
+		}
+	}
+
+	cv := conv.NewConverter(
+		conv.Def(strconv.Atoi),
+		conv.WithPairInterceptor(conv.Pair{Src: lift.T[string](), Dst: lift.T[int]()}, tag("pair")),
+		conv.WithInterceptor(tag("a"), tag("b")),
+	)
+
+	if f, ok := conv.Lookup[string, int](cv); !ok {
+		t.Fatalf("missing lookup")
+	} else if n, err := f("1"); n != 1 || err != nil {
+		t.Errorf("Lookup: %d, %v", n, err)
+	}
+	conv.To[string](cv, "s")
+	conv.ToSlice[int](cv, []string{"2"})
+
+	want := []string{
+		"a string->int", "b string->int", "pair string->int",
+		"a string->string", "b string->string",
+		"a string->int", "b string->int", "pair string->int",
+	}
+	if fmt.Sprint(seen) != fmt.Sprint(want) {
+		t.Errorf("seen %v, want %v", seen, want)
+	}
+}
+
+// Ensure a child sees conversions stored in its parent after creation,
+// and stale cached paths of the child are discarded
+func TestChildFallthrough(t *testing.T) {
+	type a int
+	type b int
+	type c int
+
+	parent := conv.NewConverter(
+		conv.Def(func(x a) (b, error) { return b(x), nil }),
+	)
+	child := parent.Child()
+	if _, ok := conv.Path[a, c](child); ok {
+		t.Errorf("unexpected path")
+	}
+
+	parent.Store(conv.Def(func(x b) (c, error) { return c(x), nil }))
+	if n, err := conv.To[c](child, a(1)); n != 1 || err != nil {
+		t.Errorf("child: %d, %v", n, err)
+	}
+
+	frozen := child.Freeze()
+	parent.Delete(conv.Def(func(x b) (c, error) { return c(x), nil }))
+	if _, err := conv.To[c](child, a(1)); err == nil {
+		t.Errorf("stale path after parent Delete")
+	}
+	if n, err := conv.To[c](frozen, a(1)); n != 1 || err != nil {
+		t.Errorf("frozen: %d, %v", n, err)
+	}
+}
+
+// Ensure concurrent conversions and definitions don't race, under -race,
+// nor do concurrent conversions filling the cache of a frozen Converter
+func TestConcurrentUse(t *testing.T) {
+	cv := conv.NewConverter(
+		conv.Def(strconv.Atoi),
+	)
+	frozen := cv.Freeze()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				if n, err := conv.To[int](frozen, "1"); n != 1 || err != nil {
+					t.Errorf("frozen: %d, %v", n, err)
+				}
+				conv.To[float64](frozen, "1")
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				conv.To[int](cv, "1")
+				conv.To[float64](cv, "1")
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				cv.Store(conv.DefInfallible(func(n int) float64 { return float64(n) }))
+				cv.Pairs()
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// Ensure Verify generates values without samples, and reports failures converting back
+func TestVerifyGenerated(t *testing.T) {
+	type small uint8
+
+	cv := conv.NewConverter(
+		conv.DefPair(
+			func(n int) (small, error) { return small(n), nil },
+			func(s small) (int, error) { return int(s), nil },
+		),
+	)
+
+	losses := conv.Verify(cv)
+	if len(losses) == 0 {
+		t.Fatalf("missing losses")
+	}
+	for _, loss := range losses {
+		if loss.String() == "" || lift.ReflectType(loss.Src) != lift.ReflectType(lift.T[int]()) {
+			t.Errorf("unexpected loss: %s", loss)
+		}
+	}
+
+	cv.Store(conv.Def(func(s small) (int, error) { return 0, errors.New("no") }))
+	if losses := conv.Verify(cv, 1); len(losses) != 1 || losses[0].Err == nil {
+		t.Errorf("missing error: %v", losses)
+	}
+}
+
+// Ensure recursive types are mapped, nil pointers and slices are left unset,
+// and strict mode reports unmapped source fields
+func TestMapStructRecursive(t *testing.T) {
+	type nodeDTO struct {
+		ID       string
+		Children []nodeDTO
+		Parent   *nodeDTO
+		Extra    bool
+	}
+	type node struct {
+		ID       int
+		Children []*node
+		Parent   *node
+	}
+
+	cv := conv.NewConverter(
+		conv.Def(strconv.Atoi),
+		conv.WithPolicy(conv.Aggregate),
+	)
+
+	src := nodeDTO{ID: "1", Children: []nodeDTO{{ID: "2"}, {ID: "x"}, {ID: "y"}}}
+	n, err := conv.MapStruct[node](cv, src)
+	var batch *conv.BatchError
+	if !errors.As(err, &batch) || len(batch.Errs) != 2 || batch.Errs[0].Key != "Children[1].ID" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n.ID != 1 || n.Parent != nil || n.Children[0].ID != 2 || n.Children[0].Children != nil || n.Children[1].ID != 0 {
+		t.Errorf("unexpected result: %+v", n)
+	}
+
+	if srcs, dsts := conv.Unmapped[node, nodeDTO](cv); fmt.Sprint(srcs, dsts) != "[Extra] []" {
+		t.Errorf("Unmapped: %v %v", srcs, dsts)
+	}
+
+	strict := conv.NewConverter(
+		conv.Def(strconv.Atoi),
+		conv.WithStructMode(conv.Strict),
+	)
+	if _, err := conv.MapStruct[node](strict, src); !errors.Is(err, conv.ErrUnmapped) {
+		t.Errorf("missing ErrUnmapped: %v", err)
+	}
+
+	// a nil source is unset, like a nil pointer
+	for _, cv := range []conv.Converter{cv, strict} {
+		if n, err := conv.MapStruct[node](cv, any(nil)); err != nil || n.ID != 0 {
+			t.Errorf("nil source: %+v, %v", n, err)
+		}
+	}
+}
+
+// Ensure Decode reports required fields and unknown keys with full paths,
+// and decodes nested maps to struct pointers
+func TestDecodePaths(t *testing.T) {
+	type db struct {
+		DSN  string `conv:"dsn,required"`
+		Pool int    `conv:"pool,default=4"`
+	}
+	type config struct {
+		DB    *db
+		Label string `conv:"label,default=a,b"`
+	}
+
+	cv := conv.NewConverter(
+		conv.Def(strconv.Atoi),
+		conv.WithStructMode(conv.Strict),
+		conv.WithPolicy(conv.Aggregate),
+	)
+
+	cfg, err := conv.Decode[config](cv, map[string]any{
+		"db": map[string]any{"dsn": "postgres://", "extra": true},
+	})
+	if !errors.Is(err, conv.ErrUnmapped) || !strings.Contains(err.Error(), "[DB.extra]") {
+		t.Errorf("missing unmapped key: %v", err)
+	}
+	if cfg.DB == nil || cfg.DB.DSN != "postgres://" || cfg.DB.Pool != 4 || cfg.Label != "a,b" {
+		t.Errorf("unexpected result: %+v", cfg)
+	}
+
+	_, err = conv.Decode[config](cv, map[string]any{
+		"db": map[string]any{"pool": nil},
+	})
+	if !errors.Is(err, conv.ErrRequired) || !strings.Contains(err.Error(), "[DB.DSN]") {
+		t.Errorf("missing required field: %v", err)
+	}
+}
+
+// Ensure nested maps decode to map fields, converting keys and values with paths by key
+func TestDecodeMaps(t *testing.T) {
+	type config struct {
+		Labels map[string]string
+		Ports  map[int]int
+	}
+
+	cv := conv.NewConverter(
+		conv.Def(strconv.Atoi),
+		conv.DefInfallible(func(f float64) int { return int(f) }),
+		conv.WithPolicy(conv.Aggregate),
+	)
+
+	cfg, err := conv.Decode[config](cv, map[string]any{
+		"labels": map[string]any{"a": "x", "b": "y"},
+		"ports":  map[string]any{"080": 1.0, "80": 8080.0, "443": "x", "http": 1.0},
+	})
+	var batch *conv.BatchError
+	if !errors.As(err, &batch) || len(batch.Errs) != 3 {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var keys []any
+	for _, e := range batch.Errs {
+		keys = append(keys, e.Key)
+	}
+	if fmt.Sprint(keys) != "[Ports[443] Ports[80] Ports[http]]" {
+		t.Errorf("unexpected keys: %v", keys)
+	}
+	if !errors.Is(batch.Errs[1].Err, conv.ErrDuplicateKey) {
+		t.Errorf("missing ErrDuplicateKey: %v", batch.Errs[1].Err)
+	}
+	if fmt.Sprint(cfg.Labels) != "map[a:x b:y]" || fmt.Sprint(cfg.Ports) != "map[80:1]" {
+		t.Errorf("unexpected result: %+v", cfg)
+	}
+}
+
+// Ensure flag values report conversion failures, and slice flags replace defaults
+func TestFlagValues(t *testing.T) {
+	cv := conv.NewConverter(
+		conv.Def(strconv.Atoi),
+	)
+
+	ns := []int{7}
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	conv.FlagVar(fs, cv, &ns, "n", "")
+
+	if err := fs.Parse([]string{"-n", "1,x"}); err == nil {
+		t.Errorf("missing error")
+	}
+	if err := fs.Parse([]string{"-n", "1", "-n", "2,3"}); err != nil || fmt.Sprint(ns) != "[1 2 3]" {
+		t.Errorf("unexpected flag: %v, %v", ns, err)
+	}
+	if got := fs.Lookup("n").Value.(flag.Getter).Get(); fmt.Sprint(got) != "[1 2 3]" {
+		t.Errorf("Get: %v", got)
+	}
+
+	if err := conv.Flags(fs, cv, ns); err == nil {
+		t.Errorf("missing error for non-struct")
+	}
+}
+
+// Ensure LoadEnv names nested variables and reports conversion failures by name
+func TestLoadEnvNested(t *testing.T) {
+	type config struct {
+		DB struct {
+			Pool int
+		}
+		Skip int `conv:"-"`
+	}
+
+	cv := conv.NewConverter(
+		conv.Def(strconv.Atoi),
+	)
+
+	t.Setenv("SVC_DB_POOL", "4")
+	var cfg config
+	if err := conv.LoadEnv(cv, &cfg, "SVC"); err != nil || cfg.DB.Pool != 4 {
+		t.Errorf("unexpected result: %+v, %v", cfg, err)
+	}
+
+	t.Setenv("SVC_DB_POOL", "four")
+	err := conv.LoadEnv(cv, &cfg, "SVC")
+	var batch *conv.BatchError
+	if !errors.As(err, &batch) || batch.Errs[0].Key != "SVC_DB_POOL" {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if cfg.DB.Pool != 4 {
+		t.Errorf("field set on failure: %d", cfg.DB.Pool)
+	}
+}
+
+// Ensure driver values round trip through the fake driver, with []byte values
+// scanned as strings, and unconvertible values reported
+func TestScannerValuer(t *testing.T) {
+	type id int
+	type tag string
+	type opaque struct{ x int }
+
+	cv := conv.NewConverter(
+		conv.DefInfallible(func(i id) int64 { return int64(i) }),
+		conv.DefInfallible(func(n int64) id { return id(n) }),
+		conv.DefInfallible(func(s string) tag { return tag(s) }),
+	)
+
+	db, err := sql.Open("convfake", fakeDSN(t.Name()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	if _, err := db.Exec("INSERT", conv.Valuer(cv, id(7))); err != nil {
+		t.Fatal(err)
+	}
+	var n id
+	if err := db.QueryRow("SELECT").Scan(conv.Scanner(cv, &n)); err != nil || n != 7 {
+		t.Errorf("Scan: %d, %v", n, err)
+	}
+
+	var tg tag
+	if err := conv.Scanner(cv, &tg).Scan([]byte("red")); err != nil || tg != "red" {
+		t.Errorf("Scan []byte: %q, %v", tg, err)
+	}
+	if err := conv.Scanner(cv, &n).Scan("seven"); err == nil {
+		t.Errorf("missing Scan error")
+	}
+	if _, err := conv.Valuer(cv, opaque{}).Value(); !errors.Is(err, conv.ErrNotFound) {
+		t.Errorf("missing Value error: %v", err)
+	}
+	if v, err := conv.Valuer(cv, (*id)(nil)).Value(); v != nil || err != nil {
+		t.Errorf("nil Value: %v, %v", v, err)
+	}
+}
+
+// Ensure direct entries are only searched and reached on their own
+func TestDirectReach(t *testing.T) {
+	type a int
+	type b int
+	type c int
+
+	cv := conv.NewConverter(
+		conv.Def(func(x a) (b, error) { return b(x), nil }).Direct(),
+		conv.Def(func(x b) (c, error) { return c(x), nil }),
+	)
+
+	if _, ok := conv.Path[a, c](cv); ok {
+		t.Errorf("unexpected chained direct entry")
+	}
+	if from := cv.From(lift.T[a]()); len(from) != 1 {
+		t.Errorf("From: %v", from)
+	}
+	if to := cv.To(lift.T[c]()); len(to) != 1 {
+		t.Errorf("To: %v", to)
+	}
+	if to := cv.To(lift.T[b]()); len(to) != 1 {
+		t.Errorf("To: %v", to)
+	}
+}
+
+// Ensure interceptor results of the wrong flavor fail, rather than panic
+func TestInterceptorResult(t *testing.T) {
+	results := []lift.Sym{nil, lift.Wrap("one"), lift.T[int]()}
+	for _, result := range results {
+		result := result
+		cv := conv.NewConverter(
+			conv.Def(strconv.Atoi),
+			conv.WithInterceptor(func(p conv.Pair, next conv.Handler) conv.Handler {
+				return func(context.Context, lift.Sym) (lift.Sym, error) {
+					return result, nil
+				}
+			}),
+		)
+
+		if _, err := conv.To[int](cv, "1"); !errors.Is(err, conv.ErrResult) {
+			t.Errorf("To: %v", err)
+		}
+		if _, err := conv.ToCtx[int](context.Background(), cv, "1"); !errors.Is(err, conv.ErrResult) {
+			t.Errorf("ToCtx: %v", err)
+		}
+		if _, err := conv.ToFrom[int](cv, lift.Wrap("1")); !errors.Is(err, conv.ErrResult) {
+			t.Errorf("ToFrom: %v", err)
+		}
+		f, _ := conv.Lookup[string, int](cv)
+		if _, err := f("1"); !errors.Is(err, conv.ErrResult) {
+			t.Errorf("Lookup: %v", err)
+		}
+	}
+}
+
+// Ensure interceptors replacing the source with the wrong flavor fail, rather than panic
+func TestInterceptorSource(t *testing.T) {
+	sources := []lift.Sym{nil, lift.Wrap(int64(1)), lift.T[string]()}
+	for _, source := range sources {
+		source := source
+		cv := conv.NewConverter(
+			conv.Def(strconv.Atoi),
+			conv.WithInterceptor(func(p conv.Pair, next conv.Handler) conv.Handler {
+				return func(ctx context.Context, _ lift.Sym) (lift.Sym, error) {
+					return next(ctx, source)
+				}
+			}),
+		)
+
+		if _, err := conv.To[int](cv, "1"); !errors.Is(err, conv.ErrSource) {
+			t.Errorf("To: %v", err)
+		}
+		f, _ := conv.Lookup[string, int](cv)
+		if _, err := f("1"); !errors.Is(err, conv.ErrSource) {
+			t.Errorf("Lookup: %v", err)
+		}
+	}
+}
+
+// Ensure scanned []byte values aren't retained, as the fake driver reuses its buffer
+func TestScannerCopiesBytes(t *testing.T) {
+	cv := conv.NewConverter()
+
+	db, err := sql.Open("convfake", fakeDSN(t.Name()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	db.Exec("INSERT", []byte("first"))
+	db.Exec("INSERT", []byte("later"))
+
+	rows, err := db.Query("SELECT")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+
+	var scanned [][]byte
+	for rows.Next() {
+		var bs []byte
+		if err := rows.Scan(conv.Scanner(cv, &bs)); err != nil {
+			t.Fatal(err)
+		}
+		scanned = append(scanned, bs)
+	}
+	if got := fmt.Sprintf("%s", scanned); got != "[first later]" {
+		t.Errorf("got %s", got)
+	}
+}
+
+// Ensure source keys converting to the same destination key are reported
+func TestToMapCollision(t *testing.T) {
+	cv := conv.NewConverter(
+		conv.DefInfallible(func(s string) int { return len(s) }),
+		conv.WithPolicy(conv.Aggregate),
+	)
+
+	m, err := conv.ToMap[int, string](cv, map[string]string{"ab": "x", "cd": "y", "e": "z"})
+	var batch *conv.BatchError
+	if !errors.As(err, &batch) || len(batch.Errs) != 1 || batch.Errs[0].Key != "cd" || !errors.Is(err, conv.ErrDuplicateKey) {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if m != nil {
+		t.Errorf("unexpected map: %v", m)
+	}
+}
+
+// Ensure negative costs are rejected
+func TestNegativeCost(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("missing panic")
+		}
+	}()
+	conv.Def(strconv.Atoi).Cost(-1)
+}
+
+// Ensure chained conversions don't register their types again on every call
+func TestLookupRegistersOnce(t *testing.T) {
+	cv := conv.NewConverter(
+		conv.DefInfallible(func(x int) int64 { return int64(x) }),
+		conv.DefInfallible(func(x int64) uint64 { return uint64(x) }),
+	)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		conv.To[uint64](cv, 1)
+	})
+	if allocs > 4 {
+		t.Errorf("%v allocations per conversion", allocs)
+	}
+}
+
+// Ensure ToCtx doesn't register its types again on every call
+func TestToCtxRegistersOnce(t *testing.T) {
+	cv := conv.NewConverter(
+		conv.DefInfallible(func(x int) int64 { return int64(x) }),
+	)
+
+	ctx := context.Background()
+	allocs := testing.AllocsPerRun(100, func() {
+		conv.ToCtx[int64](ctx, cv, 1)
+	})
+	if allocs > 2 {
+		t.Errorf("%v allocations per conversion", allocs)
+	}
+}
+
+// Ensure nil sources and destinations are reported, rather than panic
+func TestToSymNil(t *testing.T) {
+	cv := conv.NewConverter(conv.Def(strconv.Atoi))
+
+	var e *conv.Error
+	if _, err := conv.ToSym(cv, nil, lift.T[int]()); !errors.As(err, &e) || !errors.Is(err, conv.ErrUnwrapped) {
+		t.Errorf("nil source: %v", err)
+	}
+	if _, err := conv.ToSym(cv, lift.Wrap("1"), nil); !errors.As(err, &e) || !errors.Is(err, conv.ErrNoDst) {
+		t.Errorf("nil destination: %v", err)
+	}
+	if _, err := conv.ToSym(cv, nil, nil); !errors.As(err, &e) || e.Error() == "" {
+		t.Errorf("nil source and destination: %v", err)
+	}
+}
+
+// Ensure a slice of entries is accepted by NewConverter, wrapped as Entries
+func TestNewConverterEntries(t *testing.T) {
+	defs := []conv.Entry{conv.Def(strconv.Atoi), conv.DefInfallible(strconv.Itoa)}
+	cv := conv.NewConverter(conv.Entries(defs), conv.WithPolicy(conv.Aggregate))
+	if n, err := conv.To[int](cv, "1"); n != 1 || err != nil {
+		t.Errorf("got %d, %v", n, err)
+	}
+}
@@ -0,0 +1,39 @@
+// The convtest package provides helpers for testing conversions defined in a [conv.Converter],
+// in tests and fuzz targets.
+package convtest
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/AndrewHarrisSPU/lift/conv"
+)
+
+// RoundTrip reports a test failure for each value that doesn't survive a round trip,
+// as found by [conv.Verify].
+func RoundTrip(t testing.TB, cv conv.Converter, samples ...any) {
+	t.Helper()
+	for _, loss := range conv.Verify(cv, samples...) {
+		t.Errorf("convtest: lossy round trip: %s", loss)
+	}
+}
+
+// Fuzz returns a fuzz target, as in [testing.F.Fuzz], checking that values of type A
+// survive a round trip by way of type B. Values failing to convert to B are skipped.
+// Values are compared with [reflect.DeepEqual].
+func Fuzz[A any, B any](cv conv.Converter) func(*testing.T, A) {
+	return func(t *testing.T, a A) {
+		t.Helper()
+		b, err := conv.To[B](cv, a)
+		if err != nil {
+			t.Skip(err)
+		}
+		got, err := conv.To[A](cv, b)
+		if err != nil {
+			t.Fatalf("convtest: %v: %v", b, err)
+		}
+		if !reflect.DeepEqual(a, got) {
+			t.Fatalf("convtest: %v became %v, by way of %v", a, got, b)
+		}
+	}
+}
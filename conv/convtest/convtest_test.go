@@ -0,0 +1,52 @@
+package convtest_test
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/AndrewHarrisSPU/lift/conv"
+	"github.com/AndrewHarrisSPU/lift/conv/convtest"
+)
+
+func converter() conv.Converter {
+	return conv.NewConverter(
+		conv.DefPair(
+			func(n int) (string, error) { return strconv.Itoa(n), nil },
+			strconv.Atoi,
+		),
+	)
+}
+
+func TestRoundTrip(t *testing.T) {
+	convtest.RoundTrip(t, converter())
+	convtest.RoundTrip(t, converter(), 0, -1, "007x", "12")
+}
+
+func FuzzRoundTrip(f *testing.F) {
+	f.Add(0)
+	f.Add(-1 << 63)
+	f.Fuzz(convtest.Fuzz[int, string](converter()))
+}
+
+// Strings are skipped unless they are canonical decimal integers, so that
+// the round trip from string to int and back holds.
+func FuzzRoundTripSkip(f *testing.F) {
+	cv := conv.NewConverter(
+		conv.DefPair(
+			func(s string) (int, error) {
+				n, err := strconv.Atoi(s)
+				if err == nil && strconv.Itoa(n) != s {
+					err = fmt.Errorf("not canonical: %q", s)
+				}
+				return n, err
+			},
+			func(n int) (string, error) { return strconv.Itoa(n), nil },
+		),
+	)
+
+	f.Add("12")
+	f.Add("00")
+	f.Add("not a number")
+	f.Fuzz(convtest.Fuzz[string, int](cv))
+}
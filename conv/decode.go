@@ -0,0 +1,76 @@
+package conv
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// DECODING
+
+// ErrRequired is the cause of an [ElemError] when [Decode] finds no value for a required field.
+var ErrRequired = errors.New("required field missing")
+
+// Decode decodes a loosely typed map, as from JSON or YAML, to the provided DST type.
+//
+// Map keys are matched to fields as in [MapStruct], and each value is converted by the
+// [Converter], or decoded field by field or element by element. Nested maps decode to
+// structs, or to maps, converting each key and value. Struct tags may mark fields as required, or give a default value,
+// which is converted from a string:
+//
+//	Port    int    `conv:"port,default=8080"`
+//	Host    string `conv:"host,required"`
+//
+// Under the [Strict] mode, keys without a matching field fail with [ErrUnmapped].
+// Failures are reported as in [MapStruct]. Note that JSON numbers decode as float64;
+// conversions to other numeric types may be defined, or found with [WithFallback].
+func Decode[DST any](cv Converter, src map[string]any) (dst DST, err error) {
+	m := mapper{cv: cv}
+	m.value("", reflect.ValueOf(&dst).Elem(), reflect.ValueOf(src))
+	if len(m.batch.Errs) > 0 {
+		return dst, &m.batch
+	}
+	return dst, nil
+}
+
+// decode decodes a map with string keys to a struct.
+func (m *mapper) decode(path string, dst, src reflect.Value) {
+	fs := fields(dst.Type())
+
+	keys := src.MapKeys()
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].String() < keys[j].String()
+	})
+
+	found := make(map[string]bool)
+	for _, k := range keys {
+		f, ok := fs[strings.ToLower(k.String())]
+		if !ok {
+			if m.cv.cfg.structs == Strict {
+				m.fail(join(path, k.String()), fmt.Errorf("%w: no destination", ErrUnmapped))
+			}
+		} else {
+			found[f.key] = true
+			m.value(join(path, f.name), dst.FieldByIndex(f.index), src.MapIndex(k))
+		}
+		if m.done() {
+			return
+		}
+	}
+
+	for _, f := range sortedFields(fs) {
+		switch {
+		case found[f.key]:
+			continue
+		case f.hasDefault:
+			m.value(join(path, f.name), dst.FieldByIndex(f.index), reflect.ValueOf(f.dflt))
+		case f.required:
+			m.fail(join(path, f.name), ErrRequired)
+		}
+		if m.done() {
+			return
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package conv
+
+import (
+	"context"
+	"encoding"
+	"fmt"
+	"reflect"
+
+	"github.com/AndrewHarrisSPU/lift"
+)
+
+// ENCODING
+
+// WithEncodings enables conversions by way of standard interfaces, when no conversion is defined:
+//   - to string, from a [encoding.TextMarshaler] or, failing that, a [fmt.Stringer]
+//   - from string, to a [encoding.TextUnmarshaler]
+//   - from []byte, to a [encoding.BinaryUnmarshaler] or, failing that, a [encoding.TextUnmarshaler]
+//
+// Unmarshaling destinations may be pointers, or types whose pointers implement the interface,
+// but not interface types, as there is no concrete value to unmarshal into.
+// Defined conversions, including chains of defined conversions, take precedence.
+func WithEncodings() Option {
+	return option(func(cfg *config) {
+		cfg.encodings = true
+	})
+}
+
+var (
+	textMarshaler     = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	textUnmarshaler   = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	binaryUnmarshaler = reflect.TypeOf((*encoding.BinaryUnmarshaler)(nil)).Elem()
+	stringer          = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+	stringType        = reflect.TypeOf("")
+	bytesType         = reflect.TypeOf([]byte(nil))
+)
+
+// encoded resolves a conversion by way of standard interfaces.
+func (cv Converter) encoded(st, dt reflect.Type) (symFunc, bool) {
+	if _, ok := flavorOf(dt); !ok {
+		return nil, false
+	}
+
+	// an interface type implements itself, but can't be unmarshaled into
+	unmarshals := dt.Kind() != reflect.Interface
+
+	switch {
+	case dt == stringType && implements(st, textMarshaler):
+		return cv.marshal(st, textMarshaler, func(v reflect.Value) (string, error) {
+			text, err := v.Interface().(encoding.TextMarshaler).MarshalText()
+			return string(text), err
+		}), true
+	case dt == stringType && implements(st, stringer):
+		return cv.marshal(st, stringer, func(v reflect.Value) (string, error) {
+			return v.Interface().(fmt.Stringer).String(), nil
+		}), true
+	case unmarshals && st == stringType && implements(dt, textUnmarshaler):
+		return unmarshal(dt, func(p reflect.Value, src reflect.Value) error {
+			return p.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(src.String()))
+		}), true
+	case unmarshals && st == bytesType && implements(dt, binaryUnmarshaler):
+		return unmarshal(dt, func(p reflect.Value, src reflect.Value) error {
+			return p.Interface().(encoding.BinaryUnmarshaler).UnmarshalBinary(src.Bytes())
+		}), true
+	case unmarshals && st == bytesType && implements(dt, textUnmarshaler):
+		return unmarshal(dt, func(p reflect.Value, src reflect.Value) error {
+			return p.Interface().(encoding.TextUnmarshaler).UnmarshalText(src.Bytes())
+		}), true
+	}
+	return nil, false
+}
+
+// implements reports whether t, or a pointer to t, implements an interface.
+func implements(t, iface reflect.Type) bool {
+	return t.Implements(iface) || (t.Kind() != reflect.Pointer && reflect.PointerTo(t).Implements(iface))
+}
+
+// marshal converts a source to a string. Methods with pointer receivers are called on a copy of the source.
+func (cv Converter) marshal(st, iface reflect.Type, fn func(reflect.Value) (string, error)) symFunc {
+	return func(ctx context.Context, sym lift.Sym) (lift.Sym, error) {
+		v, _ := lift.ReflectValue(sym)
+		if st.Kind() == reflect.Pointer && v.IsNil() {
+			return cv.nilTo(stringType)
+		}
+		if !st.Implements(iface) {
+			p := reflect.New(st)
+			p.Elem().Set(v)
+			v = p
+		}
+
+		s, err := fn(v)
+		if err != nil {
+			return nil, err
+		}
+		return lift.Wrap(s), nil
+	}
+}
+
+// unmarshal converts a source to a new destination, or to a new value pointed to by the destination.
+func unmarshal(dt reflect.Type, fn func(p reflect.Value, src reflect.Value) error) symFunc {
+	return func(ctx context.Context, sym lift.Sym) (lift.Sym, error) {
+		v, _ := lift.ReflectValue(sym)
+
+		var p reflect.Value
+		if dt.Kind() == reflect.Pointer {
+			p = reflect.New(dt.Elem())
+		} else {
+			p = reflect.New(dt)
+		}
+		if err := fn(p, v); err != nil {
+			return nil, err
+		}
+
+		if dt.Kind() != reflect.Pointer {
+			p = p.Elem()
+		}
+		dst, _ := lift.WrapValue(p)
+		return dst, nil
+	}
+}
@@ -0,0 +1,118 @@
+package conv
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/AndrewHarrisSPU/lift"
+)
+
+// ERRORS
+
+// ErrNotFound is the cause of an [Error] when no conversion is found.
+var ErrNotFound = errors.New("conversion not found")
+
+// ErrNotOK is the cause of an [Error] when a conversion function defined with [DefOK] fails.
+var ErrNotOK = errors.New("conversion failed")
+
+// ErrUnwrapped is the cause of an [Error] when a source [lift.Sym] doesn't wrap a value.
+var ErrUnwrapped = errors.New("source is not a wrapped value")
+
+// ErrNoDst is the cause of an [Error] when the destination [lift.Sym] given to [ToSym] is nil.
+var ErrNoDst = errors.New("destination is nil")
+
+// ErrSource is the cause of an [Error] when an [Interceptor] replaces the source
+// with a value that isn't a wrapped value of the source type.
+var ErrSource = errors.New("source is not a wrapped value of the source type")
+
+// ErrResult is the cause of an [Error] when an [Interceptor] yields a result
+// that isn't a wrapped value of the destination type.
+var ErrResult = errors.New("result is not a wrapped value of the destination type")
+
+// A Pair is a source and destination type of a conversion.
+type Pair struct {
+	Src, Dst lift.Sym
+}
+
+func (p Pair) String() string {
+	return fmt.Sprintf("%s->%s", flavorName(p.Src), flavorName(p.Dst))
+}
+
+// flavor returns the flavor of a possibly nil [lift.Sym].
+func flavor(sym lift.Sym) lift.Sym {
+	if sym == nil {
+		return nil
+	}
+	return lift.Flavor(sym)
+}
+
+// An Error reports a failed conversion, from Src to Dst type.
+// The cause, found with [errors.Is] or [errors.As], is one of:
+//   - [ErrNotFound], when no conversion is found
+//   - [ErrUnwrapped] or [ErrNoDst], when [ToSym] is given a nil or unwrapped value
+//   - [ErrNil], when a nil pointer source can't be converted
+//   - [ErrNotOK], when a conversion function defined with [DefOK] fails
+//   - [ErrSource] or [ErrResult], when an [Interceptor] yields a value of the wrong flavor
+//   - the error of a [context.Context], as with [ToCtx], when it is done
+//   - an error returned by a conversion function or an [Interceptor]
+type Error struct {
+	Src, Dst lift.Sym
+	Err      error
+
+	// Near lists defined conversions from Src, or to Dst, when no conversion is found.
+	Near []Pair
+}
+
+func (e *Error) Error() string {
+	msg := fmt.Sprintf("conv: %s: %v", Pair{e.Src, e.Dst}, e.Err)
+	if len(e.Near) == 0 {
+		return msg
+	}
+
+	near := make([]string, len(e.Near))
+	for i, p := range e.Near {
+		near[i] = p.String()
+	}
+	return fmt.Sprintf("%s (near: %s)", msg, strings.Join(near, ", "))
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// notFound returns an [Error] listing near-miss conversions.
+func (cv Converter) notFound(src, dst lift.Sym) *Error {
+	var near []Pair
+	for _, e := range cv.from(src) {
+		near = append(near, Pair{e.src, e.dst})
+	}
+	for _, s := range cv.srcs() {
+		if lift.ReflectType(s) == lift.ReflectType(src) {
+			continue
+		}
+		for _, e := range cv.from(s) {
+			if lift.ReflectType(e.dst) == lift.ReflectType(dst) {
+				near = append(near, Pair{e.src, e.dst})
+			}
+		}
+	}
+
+	// stable order, with conversions from src first
+	sort.SliceStable(near, func(i, j int) bool {
+		fi := lift.ReflectType(near[i].Src) == lift.ReflectType(src)
+		fj := lift.ReflectType(near[j].Src) == lift.ReflectType(src)
+		if fi != fj {
+			return fi
+		}
+		return near[i].String() < near[j].String()
+	})
+
+	return &Error{
+		Src:  lift.Flavor(src),
+		Dst:  lift.Flavor(dst),
+		Err:  ErrNotFound,
+		Near: near,
+	}
+}
@@ -1,8 +1,19 @@
 package conv_test
 
 import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/AndrewHarrisSPU/lift"
 	"github.com/AndrewHarrisSPU/lift/conv"
 )
 
@@ -61,7 +72,7 @@ func ExampleTo_failure() {
 		fmt.Print(err.Error())
 	}
 	// Output:
-	// oops
+	// conv: int->int32: oops
 }
 
 func ExampleConverter_Delete() {
@@ -84,7 +95,7 @@ func ExampleConverter_Delete() {
 		fmt.Println(err.Error())
 	}
 	// Output:
-	// Conversion not found: bool->int
+	// conv: bool->int: conversion not found
 }
 
 func ExampleLookup() {
@@ -112,4 +123,967 @@ func ExampleLookup() {
 	// Output:
 	// #29beb0
 	// #e0b0ff
-}
\ No newline at end of file
+}
+
+// PATHS
+
+// A conversion may chain several defined conversions.
+func Example_chain() {
+	type celsius float64
+	type fahrenheit float64
+	type kelvin float64
+
+	c2f := func(c celsius) (fahrenheit, error) {
+		return fahrenheit(c*9/5 + 32), nil
+	}
+	k2c := func(k kelvin) (celsius, error) {
+		return celsius(k - 273.15), nil
+	}
+
+	cv := conv.NewConverter(
+		conv.Def(c2f),
+		conv.Def(k2c),
+	)
+
+	f, _ := conv.To[fahrenheit](cv, kelvin(373.15))
+	fmt.Printf("%.1f\n", f)
+	// Output:
+	// 212.0
+}
+
+func ExamplePath() {
+	type hex string
+	type rgb struct{ r, g, b uint8 }
+	type hsl struct{ h, s, l float64 }
+
+	cv := conv.NewConverter(
+		conv.Def(func(hex) (rgb, error) { return rgb{}, nil }),
+		conv.Def(func(rgb) (hsl, error) { return hsl{}, nil }),
+	)
+
+	path, _ := conv.Path[hex, hsl](cv)
+	for _, sym := range path {
+		fmt.Println(lift.ReflectType(sym))
+	}
+	// Output:
+	// conv_test.hex
+	// conv_test.rgb
+	// conv_test.hsl
+}
+
+// Costs weigh the choice between chains of conversions.
+func ExampleEntry_Cost() {
+	type meters float64
+	type feet float64
+	type inches float64
+
+	cv := conv.NewConverter(
+		conv.Def(func(m meters) (feet, error) { return feet(m * 3.28084), nil }),
+		conv.Def(func(f feet) (inches, error) { return inches(f * 12), nil }),
+		// a direct, but imprecise, conversion
+		conv.Def(func(m meters) (inches, error) { return inches(m * 40), nil }).Cost(3),
+	)
+
+	in, _ := conv.To[inches](cv, meters(1))
+	fmt.Printf("%.2f\n", in)
+	// Output:
+	// 39.37
+}
+
+// DYNAMIC CONVERSION
+
+// Values arriving as wrapped [lift.Sym], perhaps from a parser, can be converted
+// without knowing their types until runtime.
+func ExampleToFrom() {
+	type celsius float64
+
+	cv := conv.NewConverter(
+		conv.Def(func(n int) (celsius, error) { return celsius(n), nil }),
+		conv.Def(func(s string) (celsius, error) {
+			f, err := strconv.ParseFloat(strings.TrimSuffix(s, "C"), 64)
+			return celsius(f), err
+		}),
+	)
+
+	readings := []lift.Sym{
+		lift.Wrap(20),
+		lift.Wrap("21.5C"),
+		lift.Wrap(celsius(19)),
+		lift.Wrap(true),
+	}
+
+	for _, r := range readings {
+		if c, err := conv.ToFrom[celsius](cv, r); err != nil {
+			fmt.Println(err)
+		} else {
+			fmt.Println(c)
+		}
+	}
+	// Output:
+	// 20
+	// 21.5
+	// 19
+	// conv: bool->conv_test.celsius: conversion not found (near: int->conv_test.celsius, string->conv_test.celsius)
+}
+
+func ExampleToSym() {
+	cv := conv.NewConverter(
+		conv.Def(strconv.Atoi),
+	)
+
+	sym, _ := conv.ToSym(cv, lift.Wrap("42"), lift.T[int]())
+	fmt.Println(lift.EnumIs[int](sym), lift.MustUnwrap[int](sym))
+	// Output:
+	// true 42
+}
+
+// ERRORS
+
+func ExampleError() {
+	type rgb struct{ r, g, b uint8 }
+	type hex string
+
+	cv := conv.NewConverter(
+		conv.Def(func(h hex) (c rgb, err error) {
+			_, err = fmt.Sscanf(string(h), "#%02x%02x%02x", &c.r, &c.g, &c.b)
+			return
+		}),
+		conv.Def(func(s string) (int, error) { return strconv.Atoi(s) }),
+	)
+
+	_, err := conv.To[rgb](cv, "#ffffff")
+	fmt.Println(errors.Is(err, conv.ErrNotFound))
+
+	var cerr *conv.Error
+	if errors.As(err, &cerr) {
+		fmt.Println(cerr.Src == lift.T[string](), cerr.Dst == lift.T[rgb]())
+		for _, p := range cerr.Near {
+			fmt.Println(p)
+		}
+	}
+	// Output:
+	// true
+	// true true
+	// string->int
+	// conv_test.hex->conv_test.rgb
+}
+
+// CONTAINERS
+
+func ExampleToSlice() {
+	cv := conv.NewConverter(
+		conv.Def(strconv.Atoi),
+	)
+
+	ns, _ := conv.ToSlice[int](cv, []string{"1", "2", "3"})
+	fmt.Println(ns)
+
+	_, err := conv.ToSlice[int](cv, []string{"1", "two", "3", "four"})
+	fmt.Println(err)
+	// Output:
+	// [1 2 3]
+	// conv: failed elements: [1]: conv: string->int: strconv.Atoi: parsing "two": invalid syntax
+}
+
+func ExampleWithPolicy() {
+	cv := conv.NewConverter(
+		conv.Def(strconv.Atoi),
+		conv.WithPolicy(conv.Aggregate),
+	)
+
+	_, err := conv.ToSlice[int](cv, []string{"1", "two", "3", "four"})
+
+	var batch *conv.BatchError
+	if errors.As(err, &batch) {
+		for _, e := range batch.Errs {
+			fmt.Println(e.Key)
+		}
+	}
+	// Output:
+	// 1
+	// 3
+}
+
+func ExampleToMap() {
+	type id int
+
+	cv := conv.NewConverter(
+		conv.Def(func(s string) (id, error) {
+			n, err := strconv.Atoi(s)
+			return id(n), err
+		}),
+		conv.Def(func(f float64) (string, error) {
+			return strconv.FormatFloat(f, 'f', 2, 64), nil
+		}),
+	)
+
+	prices, _ := conv.ToMap[id, string](cv, map[string]float64{
+		"7":  1.5,
+		"11": 20,
+	})
+	fmt.Println(prices[7], prices[11])
+	// Output:
+	// 1.50 20.00
+}
+
+// Arrays are converted by slicing them.
+func ExampleFill() {
+	cv := conv.NewConverter(
+		conv.Def(func(b bool) (rune, error) {
+			if b {
+				return '1', nil
+			}
+			return '0', nil
+		}),
+	)
+
+	bits := [4]bool{true, false, true, true}
+	var digits [4]rune
+
+	conv.Fill(cv, digits[:], bits[:])
+	fmt.Println(string(digits[:]))
+	// Output:
+	// 1011
+}
+
+func ExampleToChan() {
+	cv := conv.NewConverter(
+		conv.Def(strconv.Atoi),
+		conv.WithPolicy(conv.Aggregate),
+	)
+
+	src := make(chan string)
+	go func() {
+		defer close(src)
+		for _, s := range []string{"10", "eleven", "12"} {
+			src <- s
+		}
+	}()
+
+	for res := range conv.ToChan[int](cv, src) {
+		if res.Err != nil {
+			fmt.Println("error at", res.Err.(*conv.ElemError).Key)
+			continue
+		}
+		fmt.Println(res.Value)
+	}
+	// Output:
+	// 10
+	// error at 1
+	// 12
+}
+
+// POINTERS
+
+// Pointer sources are dereferenced, and pointer destinations produced,
+// when a conversion between the underlying types is defined.
+func Example_pointers() {
+	type celsius float64
+	type fahrenheit float64
+
+	cv := conv.NewConverter(
+		conv.Def(func(c celsius) (fahrenheit, error) {
+			return fahrenheit(c*9/5 + 32), nil
+		}),
+	)
+
+	c := celsius(100)
+	f, _ := conv.To[fahrenheit](cv, &c)
+	fmt.Println(f)
+
+	fp, _ := conv.To[*fahrenheit](cv, c)
+	fmt.Println(*fp)
+
+	var missing *celsius
+	fp, err := conv.To[*fahrenheit](cv, missing)
+	fmt.Println(fp == nil, err)
+	// Output:
+	// 212
+	// 212
+	// true <nil>
+}
+
+func ExampleWithNil() {
+	cv := conv.NewConverter(
+		conv.Def(func(n int) (string, error) { return strconv.Itoa(n), nil }),
+		conv.WithNil(conv.NilToError),
+	)
+
+	var n *int
+	_, err := conv.To[*string](cv, n)
+	fmt.Println(errors.Is(err, conv.ErrNil))
+	// Output:
+	// true
+}
+
+// FALLBACK
+
+// Built-in conversions, like a named type to its underlying type, are opt-in.
+func ExampleWithFallback() {
+	type bit bool
+
+	cv := conv.NewConverter(
+		conv.WithFallback(conv.Convertible),
+	)
+
+	b, _ := conv.To[bool](cv, bit(true))
+	fmt.Println(b)
+
+	n, _ := conv.To[int8](cv, 300)
+	fmt.Println(n)
+
+	bs, _ := conv.To[[]byte](cv, "hi")
+	fmt.Println(bs)
+	// Output:
+	// true
+	// 44
+	// [104 105]
+}
+
+func ExampleWithFallback_strict() {
+	cv := conv.NewConverter(
+		conv.WithFallback(conv.StrictConvertible),
+	)
+
+	for _, f := range []float64{2, 2.5, 1e10} {
+		n, err := conv.To[int32](cv, f)
+		fmt.Println(n, errors.Is(err, conv.ErrLossy))
+	}
+	// Output:
+	// 2 false
+	// 0 true
+	// 0 true
+}
+
+// ENCODINGS
+
+type level int
+
+func (l level) String() string {
+	return [...]string{"low", "mid", "high"}[l]
+}
+
+func (l *level) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "low":
+		*l = 0
+	case "mid":
+		*l = 1
+	case "high":
+		*l = 2
+	default:
+		return fmt.Errorf("unknown level %q", text)
+	}
+	return nil
+}
+
+func ExampleWithEncodings() {
+	cv := conv.NewConverter(
+		conv.WithEncodings(),
+	)
+
+	s, _ := conv.To[string](cv, level(2))
+	fmt.Println(s)
+
+	l, _ := conv.To[level](cv, "mid")
+	fmt.Println(int(l))
+
+	lp, _ := conv.To[*level](cv, "low")
+	fmt.Println(int(*lp))
+
+	// defined conversions take precedence
+	cv.Store(conv.Def(func(l level) (string, error) {
+		return strings.ToUpper(l.String()), nil
+	}))
+	s, _ = conv.To[string](cv, level(2))
+	fmt.Println(s)
+	// Output:
+	// high
+	// 1
+	// 0
+	// HIGH
+}
+
+// INTERFACES
+
+// A source implementing an interface converts to it by type assertion.
+func ExampleTo_interface() {
+	cv := conv.NewConverter()
+
+	var b strings.Builder
+	w, _ := conv.To[io.Writer](cv, &b)
+	fmt.Fprint(w, "written")
+	fmt.Println(b.String())
+	// Output:
+	// written
+}
+
+// Defined conversions to a type implementing an interface are found, too.
+func ExampleTo_interfaceChain() {
+	type doc struct{ title, body string }
+
+	cv := conv.NewConverter(
+		conv.Def(func(d doc) (*strings.Reader, error) {
+			return strings.NewReader(d.title + "\n" + d.body), nil
+		}),
+	)
+
+	r, _ := conv.To[io.Reader](cv, doc{"Title", "text"})
+	text, _ := io.ReadAll(r)
+	fmt.Printf("%q\n", text)
+	// Output:
+	// "Title\ntext"
+}
+
+// ADAPTERS
+
+func ExampleDefInfallible() {
+	cv := conv.NewConverter(
+		conv.DefInfallible(strconv.Itoa),
+	)
+
+	s, _ := conv.To[string](cv, 7)
+	fmt.Printf("%q\n", s)
+
+	// Lookup finds the adapted conversion function
+	itoa, _ := conv.Lookup[int, string](cv)
+	s, err := itoa(8)
+	fmt.Printf("%q %v\n", s, err)
+	// Output:
+	// "7"
+	// "8" <nil>
+}
+
+func ExampleDefOK() {
+	type weekday string
+	days := map[string]weekday{"mon": "Monday", "tue": "Tuesday"}
+
+	cv := conv.NewConverter(
+		conv.DefOK(func(s string) (weekday, bool) {
+			d, ok := days[s]
+			return d, ok
+		}),
+	)
+
+	d, _ := conv.To[weekday](cv, "tue")
+	fmt.Println(d)
+
+	_, err := conv.To[weekday](cv, "someday")
+	fmt.Println(errors.Is(err, conv.ErrNotOK))
+	// Output:
+	// Tuesday
+	// true
+}
+
+func ExampleToCtx() {
+	type digest [32]byte
+
+	cv := conv.NewConverter(
+		conv.DefCtx(func(ctx context.Context, data []byte) (d digest, err error) {
+			for i := 0; i < 1000; i++ {
+				if err := ctx.Err(); err != nil {
+					return d, err
+				}
+				d[i%32] ^= data[i%len(data)]
+			}
+			return d, nil
+		}),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	_, err := conv.ToCtx[digest](ctx, cv, []byte("payload"))
+	fmt.Println(err)
+
+	cancel()
+	_, err = conv.ToCtx[digest](ctx, cv, []byte("payload"))
+	fmt.Println(errors.Is(err, context.Canceled))
+	// Output:
+	// <nil>
+	// true
+}
+
+// GRAPH
+
+func ExampleConverter_Pairs() {
+	cv := conv.NewConverter(
+		conv.Def(strconv.Atoi),
+		conv.DefInfallible(strconv.Itoa),
+		conv.DefInfallible(func(n int) float64 { return float64(n) }),
+	)
+
+	for _, p := range cv.Pairs() {
+		fmt.Println(p)
+	}
+	// Output:
+	// int->float64
+	// int->string
+	// string->int
+}
+
+func ExampleConverter_From() {
+	type hex string
+	type rgb struct{ r, g, b uint8 }
+	type hsl struct{ h, s, l float64 }
+
+	cv := conv.NewConverter(
+		conv.Def(func(hex) (rgb, error) { return rgb{}, nil }),
+		conv.Def(func(rgb) (hsl, error) { return hsl{}, nil }),
+	)
+
+	for _, sym := range cv.From(lift.T[hex]()) {
+		fmt.Println(lift.ReflectType(sym))
+	}
+	for _, sym := range cv.To(lift.T[hsl]()) {
+		fmt.Println(lift.ReflectType(sym))
+	}
+	// Output:
+	// conv_test.hsl
+	// conv_test.rgb
+	// conv_test.hex
+	// conv_test.rgb
+}
+
+// Required conversions may be checked when a program starts.
+func ExampleConverter_Require() {
+	cv := conv.NewConverter(
+		conv.Def(strconv.Atoi),
+	)
+
+	err := cv.Require(
+		conv.Pair{Src: lift.T[string](), Dst: lift.T[int]()},
+		conv.Pair{Src: lift.T[int](), Dst: lift.T[string]()},
+		conv.Pair{Src: lift.T[string](), Dst: lift.T[bool]()},
+	)
+	fmt.Println(err)
+	fmt.Println(errors.Is(err, conv.ErrNotFound))
+	// Output:
+	// conv: conversion not found: int->string, string->bool
+	// true
+}
+
+func ExampleConverter_WriteDOT() {
+	cv := conv.NewConverter(
+		conv.Def(strconv.Atoi),
+		conv.DefInfallible(strconv.Itoa).Cost(2),
+	)
+
+	cv.WriteDOT(os.Stdout)
+	// Output:
+	// digraph conv {
+	// 	rankdir=LR;
+	// 	"int" -> "string" [label="2"];
+	// 	"string" -> "int";
+	// }
+}
+
+func ExampleConverter_WriteJSON() {
+	cv := conv.NewConverter(
+		conv.Def(strconv.Atoi),
+		conv.DefInfallible(strconv.Itoa).Cost(2),
+	)
+
+	cv.WriteJSON(os.Stdout)
+	// Output:
+	// [{"src":"int","dst":"string","cost":2},{"src":"string","dst":"int","cost":1}]
+}
+
+// INTERCEPTORS
+
+func ExampleWithInterceptor() {
+	logged := conv.Observe(func(r conv.Record) {
+		fmt.Printf("%s: %v, %v\n", r.Pair, lift.MustUnwrap[string](r.In), r.Err)
+	})
+
+	cv := conv.NewConverter(
+		conv.Def(strconv.Atoi),
+		conv.WithInterceptor(logged),
+	)
+
+	conv.To[int](cv, "12")
+	conv.To[int](cv, "twelve")
+	// Output:
+	// string->int: 12, <nil>
+	// string->int: twelve, strconv.Atoi: parsing "twelve": invalid syntax
+}
+
+// Interceptors may normalize conversions between the types of a pair.
+func ExampleWithPairInterceptor() {
+	trim := func(p conv.Pair, next conv.Handler) conv.Handler {
+		return func(ctx context.Context, src lift.Sym) (lift.Sym, error) {
+			s := strings.TrimSpace(lift.MustUnwrap[string](src))
+			return next(ctx, lift.Wrap(s))
+		}
+	}
+
+	cv := conv.NewConverter(
+		conv.Def(strconv.Atoi),
+		conv.Def(strconv.ParseBool),
+		conv.WithPairInterceptor(conv.Pair{Src: lift.T[string](), Dst: lift.T[int]()}, trim),
+	)
+
+	n, err := conv.To[int](cv, " 12\n")
+	fmt.Println(n, err)
+	_, err = conv.To[bool](cv, " true\n")
+	fmt.Println(err != nil)
+	// Output:
+	// 12 <nil>
+	// true
+}
+
+// LAYERS
+
+// A child layers overrides over a shared base.
+func ExampleConverter_Child() {
+	base := conv.NewConverter(
+		conv.DefInfallible(func(b bool) string { return strconv.FormatBool(b) }),
+		conv.DefInfallible(strconv.Itoa),
+	)
+
+	tenant := base.Child()
+	tenant.Store(
+		conv.DefInfallible(func(b bool) string {
+			if b {
+				return "yes"
+			}
+			return "no"
+		}),
+	)
+
+	a, _ := conv.To[string](base, true)
+	b, _ := conv.To[string](tenant, true)
+	c, _ := conv.To[string](tenant, 1)
+	fmt.Println(a, b, c)
+	// Output:
+	// true yes 1
+}
+
+func ExampleConverter_Clone() {
+	cv := conv.NewConverter(
+		conv.Def(strconv.Atoi),
+	)
+
+	clone := cv.Clone()
+	clone.Delete(conv.Def(strconv.Atoi))
+
+	_, err := conv.To[int](cv, "1")
+	fmt.Println(err)
+	_, err = conv.To[int](clone, "1")
+	fmt.Println(errors.Is(err, conv.ErrNotFound))
+	// Output:
+	// <nil>
+	// true
+}
+
+func ExampleConverter_Freeze() {
+	cv := conv.NewConverter(
+		conv.Def(strconv.Atoi),
+	).Freeze()
+
+	defer func() {
+		fmt.Println(recover())
+	}()
+
+	n, _ := conv.To[int](cv, "1")
+	fmt.Println(n)
+	cv.Store(conv.DefInfallible(strconv.Itoa))
+	// Output:
+	// 1
+	// conv: Store of frozen Converter
+}
+
+// ROUND TRIPS
+
+// Verify finds conversions that drift in a round trip.
+func ExampleVerify() {
+	type hex string
+	type rgb struct{ R, G, B uint8 }
+
+	cv := conv.NewConverter(
+		conv.DefPair(
+			func(h hex) (c rgb, err error) {
+				_, err = fmt.Sscanf(string(h), "#%02x%02x%02x", &c.R, &c.G, &c.B)
+				return c, err
+			},
+			func(c rgb) (hex, error) {
+				// drifted: the blue channel is dropped
+				return hex(fmt.Sprintf("#%02x%02x00", c.R, c.G)), nil
+			},
+		),
+	)
+
+	losses := conv.Verify(cv, hex("#ff8000"), hex("#e0b0ff"), rgb{1, 2, 0})
+	for _, loss := range losses {
+		fmt.Println(loss)
+	}
+	// Output:
+	// conv_test.hex->conv_test.rgb: #e0b0ff became #e0b000
+}
+
+// STRUCTS
+
+func ExampleMapStruct() {
+	type AddressDTO struct {
+		Lines []string
+		Zip   string `conv:"postcode"`
+	}
+	type UserDTO struct {
+		Name    string
+		Age     string
+		Address AddressDTO
+	}
+
+	type line string
+	type address struct {
+		Lines    []line
+		Postcode int
+	}
+	type user struct {
+		Name    string
+		Age     int
+		Address *address
+	}
+
+	cv := conv.NewConverter(
+		conv.Def(strconv.Atoi),
+		conv.DefInfallible(func(s string) line { return line(s) }),
+	)
+
+	dto := UserDTO{
+		Name: "Ada",
+		Age:  "36",
+		Address: AddressDTO{
+			Lines: []string{"12 St James's Square"},
+			Zip:   "1815",
+		},
+	}
+	u, err := conv.MapStruct[user](cv, dto)
+	fmt.Println(u.Name, u.Age, u.Address.Lines, u.Address.Postcode, err)
+
+	dto.Age = "thirty-six"
+	_, err = conv.MapStruct[user](cv, dto)
+	fmt.Println(err)
+	// Output:
+	// Ada 36 [12 St James's Square] 1815 <nil>
+	// conv: failed elements: [Age]: strconv.Atoi: parsing "thirty-six": invalid syntax
+}
+
+func ExampleWithStructMode() {
+	type row struct {
+		ID    int
+		Email string
+		Notes string `conv:"-"`
+	}
+	type account struct {
+		ID      int
+		Email   string
+		Enabled bool
+	}
+
+	cv := conv.NewConverter(
+		conv.WithStructMode(conv.Strict),
+		conv.WithPolicy(conv.Aggregate),
+	)
+
+	_, err := conv.MapStruct[account](cv, row{ID: 1, Email: "a@b.c"})
+	fmt.Println(err)
+	// Output:
+	// conv: failed elements: [Enabled]: unmapped field: no source
+}
+
+func ExampleUnmapped() {
+	type v1 struct {
+		Name  string
+		Email string
+	}
+	type v2 struct {
+		Name    string
+		Contact struct{ Email, Phone string }
+	}
+
+	src, dst := conv.Unmapped[v2, v1](conv.NewConverter())
+	fmt.Println(src, dst)
+	// Output:
+	// [Email] [Contact]
+}
+
+// DECODING
+
+func ExampleDecode() {
+	type server struct {
+		Host    string        `conv:"host,required"`
+		Port    int           `conv:"port,default=8080"`
+		Timeout time.Duration `conv:"timeout,default=5s"`
+	}
+	type config struct {
+		Name    string
+		Servers []server
+	}
+
+	cv := conv.NewConverter(
+		conv.Def(strconv.Atoi),
+		conv.Def(time.ParseDuration),
+		conv.WithFallback(conv.Convertible),
+	)
+
+	var raw map[string]any
+	json.Unmarshal([]byte(`{
+		"name": "edge",
+		"servers": [
+			{"host": "a.example.com", "port": 443, "timeout": "1m"},
+			{"host": "b.example.com"}
+		]
+	}`), &raw)
+
+	cfg, err := conv.Decode[config](cv, raw)
+	fmt.Printf("%+v %v\n", cfg, err)
+
+	raw = nil
+	json.Unmarshal([]byte(`{"servers": [{"port": "http"}]}`), &raw)
+	_, err = conv.Decode[config](cv, raw)
+	fmt.Println(err)
+	// Output:
+	// {Name:edge Servers:[{Host:a.example.com Port:443 Timeout:1m0s} {Host:b.example.com Port:8080 Timeout:5s}]} <nil>
+	// conv: failed elements: [Servers[0].Port]: strconv.Atoi: parsing "http": invalid syntax
+}
+
+// FLAGS
+
+func ExampleFlag() {
+	type level int
+
+	cv := conv.NewConverter(
+		conv.Def(func(s string) (level, error) {
+			n, err := strconv.Atoi(strings.TrimPrefix(s, "L"))
+			return level(n), err
+		}),
+		conv.DefInfallible(func(l level) string { return "L" + strconv.Itoa(int(l)) }),
+	)
+
+	lvl, tags := level(1), []level{}
+	fs := flag.NewFlagSet("example", flag.ContinueOnError)
+	fs.Var(conv.Flag(cv, &lvl), "level", "log level")
+	conv.FlagVar(fs, cv, &tags, "tag", "tag levels")
+
+	fs.Parse([]string{"-level", "L3", "-tag", "L1,L2", "-tag", "L4"})
+	fmt.Println(lvl, tags)
+	fmt.Println(fs.Lookup("level").Value, fs.Lookup("tag").Value)
+	// Output:
+	// 3 [1 2 4]
+	// L3 L1,L2,L4
+}
+
+func ExampleFlags() {
+	type config struct {
+		Addr    string        `conv:"addr,default=:8080" usage:"listen address"`
+		Timeout time.Duration `conv:"timeout,default=5s" usage:"request timeout"`
+		DB      struct {
+			Pool int `usage:"connection pool size"`
+		}
+	}
+
+	cv := conv.NewConverter(
+		conv.Def(strconv.Atoi),
+		conv.DefInfallible(strconv.Itoa),
+		conv.Def(time.ParseDuration),
+		conv.DefInfallible(time.Duration.String),
+	)
+
+	var cfg config
+	fs := flag.NewFlagSet("example", flag.ContinueOnError)
+	fs.SetOutput(os.Stdout)
+	conv.Flags(fs, cv, &cfg)
+
+	fs.Parse([]string{"-db.pool", "8"})
+	fmt.Printf("%+v\n", cfg)
+	fs.PrintDefaults()
+	// Output:
+	// {Addr::8080 Timeout:5s DB:{Pool:8}}
+	//   -addr value
+	//     	listen address (default :8080)
+	//   -db.pool value
+	//     	connection pool size (default 0)
+	//   -timeout value
+	//     	request timeout (default 5s)
+}
+
+func ExampleLoadEnv() {
+	type config struct {
+		Hosts []string `conv:"hosts,required"`
+		Port  int      `conv:"port,default=8080"`
+	}
+
+	cv := conv.NewConverter(
+		conv.Def(strconv.Atoi),
+	)
+
+	os.Setenv("APP_HOSTS", "a.example.com,b.example.com")
+	defer os.Unsetenv("APP_HOSTS")
+
+	var cfg config
+	err := conv.LoadEnv(cv, &cfg, "APP")
+	fmt.Printf("%+v %v\n", cfg, err)
+
+	os.Unsetenv("APP_HOSTS")
+	err = conv.LoadEnv(cv, &config{}, "APP")
+	fmt.Println(err)
+	// Output:
+	// {Hosts:[a.example.com b.example.com] Port:8080} <nil>
+	// conv: failed elements: [APP_HOSTS]: required field missing
+}
+
+// SQL
+
+func ExampleScanner() {
+	type celsius float64
+	type reading struct {
+		Sensor string
+		Temp   celsius
+	}
+
+	cv := conv.NewConverter(
+		conv.DefInfallible(func(r reading) string {
+			return fmt.Sprintf("%s=%g", r.Sensor, r.Temp)
+		}),
+		conv.Def(func(s string) (r reading, err error) {
+			sensor, temp, _ := strings.Cut(s, "=")
+			f, err := strconv.ParseFloat(temp, 64)
+			return reading{sensor, celsius(f)}, err
+		}),
+	)
+
+	db, _ := sql.Open("convfake", fakeDSN("ExampleScanner"))
+	defer db.Close()
+
+	db.Exec("INSERT", conv.Valuer(cv, reading{"attic", 21.5}))
+	db.Exec("INSERT", nil)
+
+	rows, _ := db.Query("SELECT")
+	defer rows.Close()
+	for rows.Next() {
+		var r reading
+		err := rows.Scan(conv.Scanner(cv, &r))
+		fmt.Printf("%+v %v\n", r, err)
+	}
+	// Output:
+	// {Sensor:attic Temp:21.5} <nil>
+	// {Sensor: Temp:0} <nil>
+}
+
+// Direct entries aren't chained with other conversions.
+func ExampleEntry_Direct() {
+	cv := conv.NewConverter(
+		conv.Def(strconv.ParseBool).Direct(),
+		conv.Def(strconv.Atoi).Direct(),
+		conv.DefInfallible(strconv.FormatBool).Direct(),
+	)
+
+	n, err := conv.To[int](cv, "7")
+	fmt.Println(n, err)
+
+	// bool->string->int isn't found
+	_, err = conv.To[int](cv, true)
+	fmt.Println(errors.Is(err, conv.ErrNotFound))
+	// Output:
+	// 7 <nil>
+	// true
+}
@@ -0,0 +1,102 @@
+package conv_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+)
+
+// A fake database/sql driver, storing a single column of values.
+// Queries starting with "INSERT" append their argument; other queries return every value.
+// Like real drivers, rows reuse a buffer for []byte values.
+// Tables are named by the data source name; see fakeDSN.
+
+func init() {
+	sql.Register("convfake", fakeDriver{})
+}
+
+type fakeDriver struct{}
+
+var (
+	fakeTables sync.Map
+	fakeOpens  atomic.Int64
+)
+
+// fakeDSN returns a data source name for a new, empty table, so that
+// repeated test runs in one process don't see rows from earlier runs.
+func fakeDSN(name string) string {
+	return fmt.Sprintf("%s#%d", name, fakeOpens.Add(1))
+}
+
+func (fakeDriver) Open(name string) (driver.Conn, error) {
+	table, _ := fakeTables.LoadOrStore(name, new(fakeTable))
+	return fakeConn{table.(*fakeTable)}, nil
+}
+
+type fakeTable struct {
+	mu   sync.Mutex
+	rows []driver.Value
+}
+
+type fakeConn struct {
+	table *fakeTable
+}
+
+func (c fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return fakeStmt{c.table, len(query) >= 6 && query[:6] == "INSERT"}, nil
+}
+
+func (fakeConn) Close() error { return nil }
+
+func (fakeConn) Begin() (driver.Tx, error) { return nil, driver.ErrSkip }
+
+type fakeStmt struct {
+	table  *fakeTable
+	insert bool
+}
+
+func (fakeStmt) Close() error { return nil }
+
+func (s fakeStmt) NumInput() int {
+	if s.insert {
+		return 1
+	}
+	return 0
+}
+
+func (s fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.table.mu.Lock()
+	defer s.table.mu.Unlock()
+	s.table.rows = append(s.table.rows, args[0])
+	return driver.RowsAffected(1), nil
+}
+
+func (s fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	s.table.mu.Lock()
+	defer s.table.mu.Unlock()
+	return &fakeRows{rows: append([]driver.Value(nil), s.table.rows...)}, nil
+}
+
+type fakeRows struct {
+	rows []driver.Value
+	buf  []byte
+}
+
+func (*fakeRows) Columns() []string { return []string{"value"} }
+
+func (*fakeRows) Close() error { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	dest[0], r.rows = r.rows[0], r.rows[1:]
+	if bs, ok := dest[0].([]byte); ok {
+		r.buf = append(r.buf[:0], bs...)
+		dest[0] = r.buf
+	}
+	return nil
+}
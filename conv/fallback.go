@@ -0,0 +1,117 @@
+package conv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+
+	"github.com/AndrewHarrisSPU/lift"
+)
+
+// FALLBACK
+
+// ErrLossy is the cause of an [Error] when a [StrictConvertible] conversion loses information.
+var ErrLossy = errors.New("lossy conversion")
+
+// A Fallback determines whether a [Converter] falls back to Go's built-in conversions,
+// as in [reflect.Value.Convert], when no conversion is defined.
+type Fallback int
+
+const (
+	// NoFallback uses only defined conversions.
+	NoFallback Fallback = iota
+	// Convertible falls back to built-in conversions. Like the conversion of a named type
+	// to its underlying type, or between numeric types, these may truncate or overflow.
+	Convertible
+	// StrictConvertible falls back to built-in conversions, but numeric conversions
+	// that overflow or lose precision fail with [ErrLossy].
+	StrictConvertible
+)
+
+// WithFallback sets the [Fallback] of a [Converter]. The default is [NoFallback].
+//
+// Fallback conversions aren't chained with defined conversions.
+// Conversions of integers to strings, yielding a rune's encoding, are never used.
+// For [ToSym], the destination type must be known to [lift.Register] or a defined conversion.
+func WithFallback(f Fallback) Option {
+	return option(func(cfg *config) {
+		cfg.fallback = f
+	})
+}
+
+// convertible resolves a built-in conversion.
+func (cv Converter) convertible(st, dt reflect.Type) (symFunc, bool) {
+	if !st.ConvertibleTo(dt) {
+		return nil, false
+	}
+	if isInteger(st) && dt.Kind() == reflect.String {
+		return nil, false
+	}
+	if _, ok := flavorOf(dt); !ok {
+		return nil, false
+	}
+
+	strict := cv.cfg.fallback == StrictConvertible && isNumeric(st) && isNumeric(dt)
+	return func(ctx context.Context, sym lift.Sym) (dst lift.Sym, err error) {
+		// conversions of slices to arrays panic when the slice is too short
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("%v", r)
+			}
+		}()
+
+		v, _ := lift.ReflectValue(sym)
+		d := v.Convert(dt)
+		if strict && lossy(v, d) {
+			return nil, fmt.Errorf("%w: %v", ErrLossy, v)
+		}
+		dst, _ = lift.WrapValue(d)
+		return dst, nil
+	}, true
+}
+
+// lossy reports whether converting d back to the type of v fails to recover v,
+// or whether the sign of v is lost, which a round trip between integers can't detect.
+func lossy(v, d reflect.Value) bool {
+	if negative(v) != negative(d) {
+		return true
+	}
+	back := d.Convert(v.Type())
+	switch v.Kind() {
+	case reflect.Float32, reflect.Float64:
+		if math.IsNaN(v.Float()) {
+			return !math.IsNaN(back.Float())
+		}
+	}
+	return back.Interface() != v.Interface()
+}
+
+// negative reports whether a numeric value is less than zero.
+func negative(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() < 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() < 0
+	}
+	return false
+}
+
+func isInteger(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+	}
+	return false
+}
+
+func isNumeric(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		return true
+	}
+	return isInteger(t)
+}
@@ -0,0 +1,203 @@
+package conv
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+)
+
+// FLAGS
+
+// Flag returns a [flag.Value] setting *p, converting flag arguments from strings with the [Converter].
+// The default value is displayed by converting *p to a string, if a conversion is found.
+//
+// When no conversion from string to a slice type T is found, each flag argument is split
+// on commas and its elements are appended to *p. A default value is replaced by the first
+// argument.
+func Flag[T any](cv Converter, p *T) flag.Value {
+	return &flagValue{cv: cv, v: reflect.ValueOf(p).Elem()}
+}
+
+// FlagVar defines a flag in a [flag.FlagSet], as in [Flag].
+func FlagVar[T any](fs *flag.FlagSet, cv Converter, p *T, name, usage string) {
+	fs.Var(Flag(cv, p), name, usage)
+}
+
+// Flags defines a flag in a [flag.FlagSet] for each field of the struct pointed to by ptr,
+// as in [Flag]. Flag names are field names, or names given by tags as in [Decode],
+// lower-cased and joined to the names of nested structs by dots: "db.pool".
+// A `usage:"..."` tag gives the usage message. Default values given by tags
+// are set before flags are defined.
+func Flags(fs *flag.FlagSet, cv Converter, ptr any) error {
+	v := reflect.ValueOf(ptr)
+	if v.Kind() != reflect.Pointer || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("conv: Flags: want struct pointer, got %T", ptr)
+	}
+
+	var batch BatchError
+	walkFields(cv, v.Elem(), nil, func(names []string, f field, fv reflect.Value) {
+		value := &flagValue{cv: cv, v: fv}
+		if f.hasDefault && fv.IsZero() {
+			if err := value.Set(f.dflt); err != nil {
+				batch.Errs = append(batch.Errs, &ElemError{Key: f.name, Err: err})
+			}
+			value.set = false
+		}
+		fs.Var(value, strings.Join(names, "."), f.usage)
+	})
+	if len(batch.Errs) > 0 {
+		return &batch
+	}
+	return nil
+}
+
+// A flagValue is a [flag.Value] setting v.
+type flagValue struct {
+	cv Converter
+	v  reflect.Value
+	// set records whether a slice has been set by a flag argument
+	set bool
+}
+
+func (f *flagValue) String() string {
+	// the flag package calls String on a zero flagValue
+	if !f.v.IsValid() {
+		return ""
+	}
+	if f.isList() {
+		elems := make([]string, f.v.Len())
+		for i := range elems {
+			elems[i] = formatValue(f.cv, f.v.Index(i))
+		}
+		return strings.Join(elems, ",")
+	}
+	return formatValue(f.cv, f.v)
+}
+
+func (f *flagValue) Set(s string) error {
+	if !f.isList() {
+		return parseValue(f.cv, f.v, s)
+	}
+
+	list := f.v
+	if !f.set {
+		list = reflect.MakeSlice(f.v.Type(), 0, 0)
+	}
+	for _, elem := range strings.Split(s, ",") {
+		e := reflect.New(f.v.Type().Elem()).Elem()
+		if err := parseValue(f.cv, e, elem); err != nil {
+			return err
+		}
+		list = reflect.Append(list, e)
+	}
+	f.v.Set(list)
+	f.set = true
+	return nil
+}
+
+func (f *flagValue) Get() any {
+	return f.v.Interface()
+}
+
+// isList reports whether v is a slice without a conversion from string.
+func (f *flagValue) isList() bool {
+	if f.v.Kind() != reflect.Slice {
+		return false
+	}
+	m := mapper{cv: f.cv}
+	_, ok := m.resolve(reflect.TypeOf(""), f.v.Type())
+	return !ok
+}
+
+// parseValue converts s to the type of dst, setting dst if successful.
+func parseValue(cv Converter, dst reflect.Value, s string) error {
+	return setValue(cv, dst, reflect.ValueOf(s))
+}
+
+// setValue converts src to the type of dst, as in [MapStruct], setting dst if successful.
+func setValue(cv Converter, dst, src reflect.Value) error {
+	m := mapper{cv: cv}
+	tmp := reflect.New(dst.Type()).Elem()
+	if m.value("", tmp, src); len(m.batch.Errs) > 0 {
+		return m.batch.Errs[0].Err
+	}
+	dst.Set(tmp)
+	return nil
+}
+
+// formatValue converts v to a string, or formats v if no conversion is found.
+func formatValue(cv Converter, v reflect.Value) string {
+	m := mapper{cv: cv}
+	s := reflect.New(reflect.TypeOf("")).Elem()
+	if m.value("", s, v); len(m.batch.Errs) > 0 {
+		return fmt.Sprint(v.Interface())
+	}
+	return s.String()
+}
+
+// walkFields calls fn with the names and value of each field of a struct, descending into
+// nested structs without a conversion from string.
+func walkFields(cv Converter, v reflect.Value, names []string, fn func([]string, field, reflect.Value)) {
+	m := mapper{cv: cv}
+	for _, f := range sortedFields(fields(v.Type())) {
+		fv := v.FieldByIndex(f.index)
+		fnames := append(names[:len(names):len(names)], f.key)
+		if _, ok := m.resolve(reflect.TypeOf(""), f.typ); !ok && f.typ.Kind() == reflect.Struct {
+			walkFields(cv, fv, fnames, fn)
+			continue
+		}
+		fn(fnames, f, fv)
+	}
+}
+
+// ENVIRONMENT
+
+// LoadEnv sets the fields of the struct pointed to by ptr from environment variables,
+// converting values from strings as in [Flag]. Variable names are field names, or names
+// given by tags as in [Decode], upper-cased and joined to the prefix and the names of
+// nested structs by underscores: with prefix "APP", "APP_DB_POOL".
+//
+// When a variable is unset, a default value given by a tag is set in a zero-valued field,
+// and a required field fails with [ErrRequired]. Failures are reported as a [*BatchError],
+// holding an [*ElemError] keyed by variable name.
+func LoadEnv(cv Converter, ptr any, prefix string) error {
+	v := reflect.ValueOf(ptr)
+	if v.Kind() != reflect.Pointer || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("conv: LoadEnv: want struct pointer, got %T", ptr)
+	}
+
+	var names []string
+	if prefix != "" {
+		names = []string{prefix}
+	}
+
+	var batch BatchError
+	walkFields(cv, v.Elem(), names, func(names []string, f field, fv reflect.Value) {
+		if cv.cfg.policy == FailFast && len(batch.Errs) > 0 {
+			return
+		}
+
+		name := strings.ToUpper(strings.Join(names, "_"))
+		s, ok := os.LookupEnv(name)
+		switch {
+		case ok:
+		case f.hasDefault && fv.IsZero():
+			s = f.dflt
+		case f.required:
+			batch.Errs = append(batch.Errs, &ElemError{Key: name, Err: ErrRequired})
+			return
+		default:
+			return
+		}
+
+		if err := (&flagValue{cv: cv, v: fv}).Set(s); err != nil {
+			batch.Errs = append(batch.Errs, &ElemError{Key: name, Err: err})
+		}
+	})
+	if len(batch.Errs) > 0 {
+		return &batch
+	}
+	return nil
+}
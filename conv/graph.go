@@ -0,0 +1,144 @@
+package conv
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/AndrewHarrisSPU/lift"
+)
+
+// GRAPH
+
+// entries returns every defined conversion, ordered by source and destination type name.
+func (cv Converter) entries() []Entry {
+	var entries []Entry
+	for _, src := range cv.srcs() {
+		entries = append(entries, cv.from(src)...)
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return lift.ReflectType(entries[i].src).String() < lift.ReflectType(entries[j].src).String()
+	})
+	return entries
+}
+
+// Pairs returns the source and destination types of every defined conversion,
+// ordered by source and destination type name.
+func (cv Converter) Pairs() []Pair {
+	entries := cv.entries()
+	pairs := make([]Pair, len(entries))
+	for i, e := range entries {
+		pairs[i] = Pair{e.src, e.dst}
+	}
+	return pairs
+}
+
+// From returns the types reachable from a source type by defined conversions,
+// ordered by type name.
+func (cv Converter) From(src lift.Sym) []lift.Sym {
+	return reach(src, cv.entries(), func(e Entry) (lift.Sym, lift.Sym) {
+		return e.src, e.dst
+	})
+}
+
+// To returns the types reaching a destination type by defined conversions,
+// ordered by type name.
+func (cv Converter) To(dst lift.Sym) []lift.Sym {
+	return reach(dst, cv.entries(), func(e Entry) (lift.Sym, lift.Sym) {
+		return e.dst, e.src
+	})
+}
+
+// reach collects types reachable from start, following edges in the given direction.
+// Direct entries are only followed from start, and the types they reach aren't followed further.
+func reach(start lift.Sym, entries []Entry, edge func(Entry) (from, to lift.Sym)) []lift.Sym {
+	st := lift.ReflectType(start)
+	listed := map[reflect.Type]bool{st: true}
+	queued := map[reflect.Type]bool{st: true}
+	queue := []reflect.Type{st}
+	var syms []lift.Sym
+
+	for len(queue) > 0 {
+		t := queue[0]
+		queue = queue[1:]
+		for _, e := range entries {
+			from, to := edge(e)
+			if lift.ReflectType(from) != t || e.direct && t != st {
+				continue
+			}
+			tt := lift.ReflectType(to)
+			if !listed[tt] {
+				listed[tt] = true
+				syms = append(syms, to)
+			}
+			if !e.direct && !queued[tt] {
+				queued[tt] = true
+				queue = append(queue, tt)
+			}
+		}
+	}
+
+	sort.Slice(syms, func(i, j int) bool {
+		return lift.ReflectType(syms[i]).String() < lift.ReflectType(syms[j]).String()
+	})
+	return syms
+}
+
+// Require reports conversions a [Converter] can't perform, with an error wrapping [ErrNotFound].
+// Beyond defined conversions, conversions enabled by options are considered.
+func (cv Converter) Require(pairs ...Pair) error {
+	var missing []string
+	for _, p := range pairs {
+		if _, ok := cv.resolve(p.Src, p.Dst); !ok {
+			missing = append(missing, p.String())
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("conv: %w: %s", ErrNotFound, strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// EXPORT
+
+// WriteDOT writes a Graphviz DOT description of the defined conversions of a [Converter].
+// Edges are labeled with costs other than 1.
+func (cv Converter) WriteDOT(w io.Writer) error {
+	var b strings.Builder
+	b.WriteString("digraph conv {\n")
+	b.WriteString("\trankdir=LR;\n")
+	for _, e := range cv.entries() {
+		fmt.Fprintf(&b, "\t%q -> %q", lift.ReflectType(e.src), lift.ReflectType(e.dst))
+		if e.cost != 1 {
+			fmt.Fprintf(&b, " [label=\"%d\"]", e.cost)
+		}
+		b.WriteString(";\n")
+	}
+	b.WriteString("}\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+type jsonConversion struct {
+	Src  string `json:"src"`
+	Dst  string `json:"dst"`
+	Cost int    `json:"cost"`
+}
+
+// WriteJSON writes the defined conversions of a [Converter] as a JSON array,
+// with the source and destination type names and cost of each conversion.
+func (cv Converter) WriteJSON(w io.Writer) error {
+	conversions := []jsonConversion{}
+	for _, e := range cv.entries() {
+		conversions = append(conversions, jsonConversion{
+			Src:  lift.ReflectType(e.src).String(),
+			Dst:  lift.ReflectType(e.dst).String(),
+			Cost: e.cost,
+		})
+	}
+	return json.NewEncoder(w).Encode(conversions)
+}
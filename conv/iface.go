@@ -0,0 +1,59 @@
+package conv
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/AndrewHarrisSPU/lift"
+)
+
+// INTERFACES
+
+// asserted resolves a conversion to an interface type by type assertion.
+func (cv Converter) asserted(st, dt reflect.Type) (symFunc, bool) {
+	if !st.Implements(dt) && st.Kind() != reflect.Interface {
+		return nil, false
+	}
+
+	return func(ctx context.Context, sym lift.Sym) (lift.Sym, error) {
+		v, _ := lift.ReflectValue(sym)
+		if v.Kind() == reflect.Interface {
+			if v.IsNil() {
+				return cv.nilTo(dt)
+			}
+			v = v.Elem()
+		}
+		if !v.Type().Implements(dt) {
+			return nil, fmt.Errorf("%s does not implement %s", v.Type(), dt)
+		}
+		return assign(v, dt), nil
+	}, true
+}
+
+// implementing resolves a conversion to an interface type by way of a type implementing it.
+func (cv Converter) implementing(src lift.Sym, dt reflect.Type) (symFunc, bool) {
+	path := cv.search(src, func(t reflect.Type) bool {
+		return t.Implements(dt)
+	})
+	if path == nil {
+		return nil, false
+	}
+
+	return func(ctx context.Context, sym lift.Sym) (lift.Sym, error) {
+		sym, err := run(ctx, path, sym)
+		if err != nil {
+			return nil, err
+		}
+		v, _ := lift.ReflectValue(sym)
+		return assign(v, dt), nil
+	}, true
+}
+
+// assign wraps a value as the flavor of an interface type it implements.
+func assign(v reflect.Value, dt reflect.Type) lift.Sym {
+	d := reflect.New(dt).Elem()
+	d.Set(v)
+	sym, _ := lift.WrapValue(d)
+	return sym
+}
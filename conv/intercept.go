@@ -0,0 +1,123 @@
+package conv
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/AndrewHarrisSPU/lift"
+)
+
+// INTERCEPTORS
+
+// A Handler converts a wrapped value, yielding a wrapped value.
+type Handler func(ctx context.Context, src lift.Sym) (lift.Sym, error)
+
+// An Interceptor wraps the [Handler] performing a conversion between the types of a [Pair].
+// An Interceptor may inspect or replace the source, the result, or the error,
+// or it may fail without calling next. A replaced source must be a wrapped value
+// of the source type; otherwise, the conversion fails with [ErrSource]. Likewise,
+// a replaced result must be a wrapped value of the destination type; otherwise,
+// the conversion fails with [ErrResult].
+//
+// Interceptors see each conversion requested of a [Converter], as by [To], [ToSym], or [Lookup].
+// A chain of defined conversions is seen as a single conversion.
+type Interceptor func(p Pair, next Handler) Handler
+
+// WithInterceptor adds interceptors seeing every conversion.
+// Interceptors are applied in order, the first seeing a conversion first.
+func WithInterceptor(ics ...Interceptor) Option {
+	return option(func(cfg *config) {
+		cfg.interceptors = append(cfg.interceptors, ics...)
+	})
+}
+
+// WithPairInterceptor adds interceptors seeing conversions between the types of a [Pair].
+// These are applied after interceptors added by [WithInterceptor].
+func WithPairInterceptor(p Pair, ics ...Interceptor) Option {
+	return option(func(cfg *config) {
+		if cfg.pairInterceptors == nil {
+			cfg.pairInterceptors = make(map[[2]reflect.Type][]Interceptor)
+		}
+		key := [2]reflect.Type{lift.ReflectType(p.Src), lift.ReflectType(p.Dst)}
+		cfg.pairInterceptors[key] = append(cfg.pairInterceptors[key], ics...)
+	})
+}
+
+// intercepts reports whether any interceptors are configured.
+func (cv Converter) intercepts() bool {
+	return len(cv.cfg.interceptors) > 0 || len(cv.cfg.pairInterceptors) > 0
+}
+
+// intercept wraps a resolved conversion with configured interceptors.
+func (cv Converter) intercept(src, dst lift.Sym, convFunc symFunc) symFunc {
+	key := [2]reflect.Type{lift.ReflectType(src), lift.ReflectType(dst)}
+	var ics []Interceptor
+	ics = append(ics, cv.cfg.interceptors...)
+	ics = append(ics, cv.cfg.pairInterceptors[key]...)
+	if len(ics) == 0 {
+		return convFunc
+	}
+
+	// interceptors may replace sources, so sources are checked before they are converted
+	st := lift.ReflectType(src)
+	h := Handler(func(ctx context.Context, sym lift.Sym) (lift.Sym, error) {
+		if _, ok := lift.ReflectValue(sym); !ok || lift.ReflectType(sym) != st {
+			return nil, fmt.Errorf("%w: %s", ErrSource, flavorName(sym))
+		}
+		return convFunc(ctx, sym)
+	})
+
+	p := Pair{lift.Flavor(src), lift.Flavor(dst)}
+	for i := len(ics) - 1; i >= 0; i-- {
+		h = ics[i](p, h)
+	}
+
+	// interceptors may replace results, so results are checked before they are unwrapped
+	dt := lift.ReflectType(dst)
+	return func(ctx context.Context, sym lift.Sym) (lift.Sym, error) {
+		out, err := h(ctx, sym)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := lift.ReflectValue(out); !ok || lift.ReflectType(out) != dt {
+			return nil, fmt.Errorf("%w: %s", ErrResult, flavorName(out))
+		}
+		return out, nil
+	}
+}
+
+// flavorName names the flavor of a possibly nil [lift.Sym].
+func flavorName(sym lift.Sym) string {
+	if sym == nil {
+		return "nil"
+	}
+	return lift.ReflectType(sym).String()
+}
+
+// A Record describes a conversion, as seen by [Observe].
+type Record struct {
+	Pair
+	In, Out  lift.Sym
+	Err      error
+	Duration time.Duration
+}
+
+// Observe returns an [Interceptor] calling fn with a [Record] of each conversion, after it completes.
+func Observe(fn func(Record)) Interceptor {
+	return func(p Pair, next Handler) Handler {
+		return func(ctx context.Context, src lift.Sym) (lift.Sym, error) {
+			start := time.Now()
+			dst, err := next(ctx, src)
+			fn(Record{
+				Pair:     p,
+				In:       src,
+				Out:      dst,
+				Err:      err,
+				Duration: time.Since(start),
+			})
+			return dst, err
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package conv
+
+import (
+	"reflect"
+	"sync"
+
+	"github.com/AndrewHarrisSPU/lift"
+)
+
+// LAYERS
+
+// state guards the maps of a [Converter].
+type state struct {
+	// mu guards edges
+	mu sync.RWMutex
+	// paths caches found paths by source and destination type, and is read without locking
+	paths sync.Map
+	// cacheMu serializes clearing and filling paths, and guards cached
+	cacheMu sync.Mutex
+	// gen counts modifications, and cached is the generation of cached paths
+	gen, cached uint64
+	// a frozen Converter is never modified, so its cached paths are never cleared
+	frozen bool
+}
+
+func newConverter(cfg *config, parent *Converter) Converter {
+	return Converter{
+		edges:  lift.NewMap[lift.Map[Entry]](),
+		cfg:    cfg,
+		state:  new(state),
+		parent: parent,
+	}
+}
+
+// lock acquires the write lock of a [Converter], panicking if it is frozen.
+func (cv Converter) lock(method string) {
+	if cv.state.frozen {
+		panic("conv: " + method + " of frozen Converter")
+	}
+	cv.state.mu.Lock()
+}
+
+// clone copies a config.
+func (cfg *config) clone() *config {
+	c := *cfg
+	c.interceptors = append([]Interceptor(nil), cfg.interceptors...)
+	c.pairInterceptors = nil
+	for k, ics := range cfg.pairInterceptors {
+		if c.pairInterceptors == nil {
+			c.pairInterceptors = make(map[[2]reflect.Type][]Interceptor)
+		}
+		c.pairInterceptors[k] = append([]Interceptor(nil), ics...)
+	}
+	return &c
+}
+
+// Clone returns a copy of a [Converter]. Conversions stored in or deleted from
+// the copy don't affect the original, and vice versa. The copy of a frozen
+// Converter isn't frozen. A parent, as in [Converter.Child], is shared.
+func (cv Converter) Clone() Converter {
+	clone := newConverter(cv.cfg.clone(), cv.parent)
+
+	cv.state.mu.RLock()
+	defer cv.state.mu.RUnlock()
+	for _, src := range cv.edges.Keys() {
+		dsts, _ := lift.LoadSym(cv.edges, src)
+		for _, dst := range dsts.Keys() {
+			e, _ := lift.LoadSym(dsts, dst)
+			clone.store(e)
+		}
+	}
+	return clone
+}
+
+// Child returns a [Converter] layered over its parent. Conversions defined in the child
+// take precedence; otherwise, conversions defined in the parent are found, including
+// those stored in the parent after the child is created. The child starts with the
+// parent's options.
+func (cv Converter) Child() Converter {
+	return newConverter(cv.cfg.clone(), &cv)
+}
+
+// Freeze returns an immutable copy of a [Converter], flattening any parents.
+// Calling Store or Delete on a frozen Converter panics.
+// Lookups needn't consult parents, and paths found remain cached,
+// read without locking or checking for modifications.
+func (cv Converter) Freeze() Converter {
+	frozen := newConverter(cv.cfg.clone(), nil)
+	for _, src := range cv.srcs() {
+		for _, e := range cv.from(src) {
+			frozen.store(e)
+		}
+	}
+	frozen.state.frozen = true
+	return frozen
+}
+
+// store defines a conversion, without locking or invalidating cached paths.
+func (cv Converter) store(def Entry) {
+	dsts, ok := lift.LoadSym(cv.edges, def.src)
+	if !ok {
+		dsts = lift.NewMap[Entry]()
+		cv.edges.Store(lift.DefSym(def.src, dsts))
+	}
+	dsts.Store(lift.DefSym(def.dst, def))
+}
@@ -0,0 +1,254 @@
+package conv
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"sync/atomic"
+
+	"github.com/AndrewHarrisSPU/lift"
+)
+
+// PATHS
+
+// Path returns the types a conversion from SRC to DST passes through, if a conversion is found.
+// The first type is SRC, and the last is DST.
+func Path[SRC any, DST any](cv Converter) ([]lift.Sym, bool) {
+	path, ok := cv.path(lift.T[SRC](), lift.T[DST]())
+	if !ok {
+		return nil, false
+	}
+	syms := []lift.Sym{lift.T[SRC]()}
+	for _, e := range path {
+		syms = append(syms, e.dst)
+	}
+	return syms, true
+}
+
+// path returns the cheapest chain of entries converting src to dst.
+// Results, including failures, are cached until the [Converter] is modified.
+// Cached results are read without locking.
+func (cv Converter) path(src, dst lift.Sym) ([]Entry, bool) {
+	key := [2]reflect.Type{lift.ReflectType(src), lift.ReflectType(dst)}
+
+	// a frozen Converter is never modified, so its cache is never stale
+	var gen uint64
+	if !cv.state.frozen {
+		gen = cv.refresh()
+	}
+
+	if cached, ok := cv.state.paths.Load(key); ok {
+		path := cached.([]Entry)
+		return path, path != nil
+	}
+
+	path := cv.search(src, func(t reflect.Type) bool {
+		return t == key[1]
+	})
+
+	if cv.state.frozen {
+		cv.state.paths.Store(key, path)
+		return path, path != nil
+	}
+
+	// a path found before a modification isn't cached after the cache is cleared
+	cv.state.cacheMu.Lock()
+	if cv.state.cached == gen {
+		cv.state.paths.Store(key, path)
+	}
+	cv.state.cacheMu.Unlock()
+	return path, path != nil
+}
+
+// refresh clears cached paths if the [Converter] or its parents were modified
+// since they were found. It returns the generation of cached paths.
+func (cv Converter) refresh() uint64 {
+	gen := cv.generation()
+	if gen == atomic.LoadUint64(&cv.state.cached) {
+		return gen
+	}
+
+	cv.state.cacheMu.Lock()
+	defer cv.state.cacheMu.Unlock()
+	if gen != cv.state.cached {
+		cv.state.paths.Range(func(k, _ any) bool {
+			cv.state.paths.Delete(k)
+			return true
+		})
+		atomic.StoreUint64(&cv.state.cached, gen)
+	}
+	return gen
+}
+
+// search finds the cheapest chain of entries converting src to a type matching a predicate,
+// by Dijkstra's algorithm. Among chains of equal cost, the chain with fewer conversions
+// is preferred, and then the chain ending in the type of least name.
+// A direct entry, as in [Entry.Direct], is only a chain of its own.
+func (cv Converter) search(src lift.Sym, match func(reflect.Type) bool) []Entry {
+	// a type reached by a direct entry is a separate, terminal node
+	type key struct {
+		t      reflect.Type
+		direct bool
+	}
+	type node struct {
+		key
+		cost, hops int
+		via        *Entry
+		done       bool
+	}
+	less := func(a, b *node) bool {
+		if a.cost != b.cost {
+			return a.cost < b.cost
+		}
+		if a.hops != b.hops {
+			return a.hops < b.hops
+		}
+		return a.t.String() < b.t.String()
+	}
+
+	// nodes are keyed by reflect.Type, as src may be wrapped
+	start := key{t: lift.ReflectType(src)}
+	nodes := map[key]*node{start: {key: start}}
+
+	var cur *node
+	for {
+		// select the cheapest unfinished node
+		cur = nil
+		for _, n := range nodes {
+			if !n.done && (cur == nil || less(n, cur)) {
+				cur = n
+			}
+		}
+		if cur == nil {
+			return nil
+		}
+		if match(cur.t) {
+			break
+		}
+		cur.done = true
+		if cur.direct {
+			continue
+		}
+
+		sym := src
+		if cur.via != nil {
+			sym = cur.via.dst
+		}
+		for _, e := range cv.from(sym) {
+			e := e
+			if e.direct && cur.via != nil {
+				continue
+			}
+			next := &node{key: key{lift.ReflectType(e.dst), e.direct}, cost: cur.cost + e.cost, hops: cur.hops + 1, via: &e}
+			n, ok := nodes[next.key]
+			switch {
+			case !ok:
+				nodes[next.key] = next
+			case n.done:
+			case less(next, n):
+				*n = *next
+			}
+		}
+	}
+
+	// walk back from the matching node
+	path := make([]Entry, cur.hops)
+	for n, i := cur, len(path)-1; i >= 0; i-- {
+		path[i] = *n.via
+		n = nodes[key{t: lift.ReflectType(n.via.src)}]
+	}
+	return path
+}
+
+// from returns the entries converting from src, ordered by destination type name.
+// Entries defined by a parent are included, unless overridden.
+func (cv Converter) from(src lift.Sym) []Entry {
+	var entries []Entry
+	if cv.parent != nil {
+		entries = cv.parent.from(src)
+	}
+
+	cv.state.mu.RLock()
+	dsts, ok := lift.LoadSym(cv.edges, src)
+	if ok {
+		for _, dst := range dsts.Keys() {
+			e, _ := lift.LoadSym(dsts, dst)
+			entries = override(entries, e)
+		}
+	}
+	cv.state.mu.RUnlock()
+
+	sort.Slice(entries, func(i, j int) bool {
+		return lift.ReflectType(entries[i].dst).String() < lift.ReflectType(entries[j].dst).String()
+	})
+	return entries
+}
+
+// override replaces the entry with the destination type of e, or appends e.
+func override(entries []Entry, e Entry) []Entry {
+	for i := range entries {
+		if lift.ReflectType(entries[i].dst) == lift.ReflectType(e.dst) {
+			entries[i] = e
+			return entries
+		}
+	}
+	return append(entries, e)
+}
+
+// srcs returns the source types of defined conversions, including those defined by a parent.
+func (cv Converter) srcs() []lift.Sym {
+	var srcs []lift.Sym
+	if cv.parent != nil {
+		srcs = cv.parent.srcs()
+	}
+
+	cv.state.mu.RLock()
+	defer cv.state.mu.RUnlock()
+	for _, src := range cv.edges.Keys() {
+		if dsts, _ := lift.LoadSym(cv.edges, src); dsts.Len() == 0 {
+			continue
+		}
+		if !contains(srcs, src) {
+			srcs = append(srcs, src)
+		}
+	}
+	return srcs
+}
+
+func contains(syms []lift.Sym, sym lift.Sym) bool {
+	for _, s := range syms {
+		if lift.ReflectType(s) == lift.ReflectType(sym) {
+			return true
+		}
+	}
+	return false
+}
+
+// run applies a chain of entries. The context is checked before each conversion.
+func run(ctx context.Context, path []Entry, sym lift.Sym) (lift.Sym, error) {
+	for _, e := range path {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		var err error
+		if sym, err = e.call(ctx, sym); err != nil {
+			return nil, err
+		}
+	}
+	return sym, nil
+}
+
+// invalidate marks cached paths as stale, for this [Converter] and any children.
+func (cv Converter) invalidate() {
+	atomic.AddUint64(&cv.state.gen, 1)
+}
+
+// generation sums the modification counts of a [Converter] and its parents.
+// Cached paths are valid while the generation is unchanged.
+func (cv Converter) generation() uint64 {
+	gen := atomic.LoadUint64(&cv.state.gen)
+	if cv.parent != nil {
+		gen += cv.parent.generation()
+	}
+	return gen
+}
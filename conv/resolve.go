@@ -0,0 +1,174 @@
+package conv
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+
+	"github.com/AndrewHarrisSPU/lift"
+)
+
+// RESOLUTION
+
+// A symFunc converts a wrapped value, yielding a wrapped value.
+// Conversion functions defined with [DefCtx] observe the context.
+type symFunc = func(context.Context, lift.Sym) (lift.Sym, error)
+
+// resolve returns a function converting wrapped values of the flavor of src to the flavor of dst.
+// Beyond chains of defined conversions, interface destinations are resolved as in the package documentation.
+// When enabled, conversions by way of standard interfaces are used. Pointer sources are dereferenced,
+// and pointer destinations are produced. Finally, when enabled, built-in conversions are used.
+func (cv Converter) resolve(src, dst lift.Sym) (symFunc, bool) {
+	st, dt := lift.ReflectType(src), lift.ReflectType(dst)
+	if st == dt {
+		return func(ctx context.Context, sym lift.Sym) (lift.Sym, error) {
+			return sym, nil
+		}, true
+	}
+
+	if path, ok := cv.path(src, dst); ok {
+		return func(ctx context.Context, sym lift.Sym) (lift.Sym, error) {
+			return run(ctx, path, sym)
+		}, true
+	}
+
+	if _, ok := flavorOf(dt); ok && dt.Kind() == reflect.Interface {
+		if convFunc, ok := cv.asserted(st, dt); ok {
+			return convFunc, true
+		}
+		if convFunc, ok := cv.implementing(src, dt); ok {
+			return convFunc, true
+		}
+	}
+	if cv.cfg.encodings {
+		if convFunc, ok := cv.encoded(st, dt); ok {
+			return convFunc, true
+		}
+	}
+	if st.Kind() == reflect.Pointer {
+		if convFunc, ok := cv.deref(st, dst); ok {
+			return convFunc, true
+		}
+	}
+	if dt.Kind() == reflect.Pointer {
+		if convFunc, ok := cv.addr(src, dt); ok {
+			return convFunc, true
+		}
+	}
+	if cv.cfg.fallback != NoFallback {
+		if convFunc, ok := cv.convertible(st, dt); ok {
+			return convFunc, true
+		}
+	}
+	return nil, false
+}
+
+// deref resolves a conversion from a pointer type, by way of the pointer's element type.
+func (cv Converter) deref(st reflect.Type, dst lift.Sym) (symFunc, bool) {
+	elem, ok := flavorOf(st.Elem())
+	if !ok {
+		return nil, false
+	}
+	convFunc, ok := cv.resolve(elem, dst)
+	if !ok {
+		return nil, false
+	}
+
+	return func(ctx context.Context, sym lift.Sym) (lift.Sym, error) {
+		v, _ := lift.ReflectValue(sym)
+		if v.IsNil() {
+			return cv.nilTo(lift.ReflectType(dst))
+		}
+		e, _ := lift.WrapValue(v.Elem())
+		return convFunc(ctx, e)
+	}, true
+}
+
+// addr resolves a conversion to a pointer type, by way of the pointer's element type.
+func (cv Converter) addr(src lift.Sym, dt reflect.Type) (symFunc, bool) {
+	elem, ok := flavorOf(dt.Elem())
+	if !ok {
+		return nil, false
+	}
+	if _, ok := flavorOf(dt); !ok {
+		return nil, false
+	}
+	convFunc, ok := cv.resolve(src, elem)
+	if !ok {
+		return nil, false
+	}
+
+	return func(ctx context.Context, sym lift.Sym) (lift.Sym, error) {
+		e, err := convFunc(ctx, sym)
+		if err != nil {
+			return nil, err
+		}
+		v, _ := lift.ReflectValue(e)
+		p := reflect.New(dt.Elem())
+		p.Elem().Set(v)
+		ptr, _ := lift.WrapValue(p)
+		return ptr, nil
+	}, true
+}
+
+// NILS
+
+// ErrNil is the cause of an [Error] when a nil pointer source can't be converted.
+var ErrNil = errors.New("nil source")
+
+// A NilPolicy determines how nil pointer sources are converted.
+type NilPolicy int
+
+const (
+	// NilToNil converts nil pointer sources to nil destinations.
+	// Destinations that can't be nil fail with [ErrNil].
+	NilToNil NilPolicy = iota
+	// NilToError fails to convert nil pointer sources, with [ErrNil].
+	NilToError
+)
+
+// WithNil sets the [NilPolicy] of a [Converter]. The default is [NilToNil].
+func WithNil(p NilPolicy) Option {
+	return option(func(cfg *config) {
+		cfg.nils = p
+	})
+}
+
+// nilTo converts a nil pointer source to dt.
+func (cv Converter) nilTo(dt reflect.Type) (lift.Sym, error) {
+	if cv.cfg.nils == NilToError {
+		return nil, ErrNil
+	}
+	switch dt.Kind() {
+	case reflect.Pointer, reflect.Interface, reflect.Map, reflect.Slice, reflect.Chan, reflect.Func:
+		if sym, ok := lift.WrapValue(reflect.Zero(dt)); ok {
+			return sym, nil
+		}
+	}
+	return nil, ErrNil
+}
+
+// REGISTRATION
+
+// registered holds the types recorded by register.
+var registered sync.Map
+
+// register records T and *T with lift, so that converted values may be wrapped by reflection.
+// Each type is recorded once; later calls only consult registered.
+func register[T any]() {
+	if _, done := registered.LoadOrStore(reflect.TypeOf((*T)(nil)), struct{}{}); done {
+		return
+	}
+	lift.Register[T]()
+	lift.Register[*T]()
+}
+
+// flavorOf returns the type enumeration of a type recorded by [lift.Register].
+func flavorOf(t reflect.Type) (lift.Sym, bool) {
+	sym, ok := lift.WrapValue(reflect.Zero(t))
+	if !ok {
+		return nil, false
+	}
+	return lift.Flavor(sym), true
+}
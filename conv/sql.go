@@ -0,0 +1,118 @@
+package conv
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/AndrewHarrisSPU/lift"
+)
+
+// SQL
+
+// driverTypes are the types of [driver.Value], in order of preference.
+var driverTypes = []reflect.Type{
+	reflect.TypeOf(int64(0)),
+	reflect.TypeOf(float64(0)),
+	reflect.TypeOf(false),
+	reflect.TypeOf(time.Time{}),
+	reflect.TypeOf(""),
+	reflect.TypeOf([]byte(nil)),
+}
+
+func init() {
+	register[time.Time]()
+	register[[]byte]()
+}
+
+// Scanner returns a [sql.Scanner] setting *p, converting driver values with the [Converter].
+// When a []byte value can't be converted, it is converted as a string.
+// A NULL value sets *p to its zero value.
+func Scanner[T any](cv Converter, p *T) sql.Scanner {
+	return scanner[T]{cv, p}
+}
+
+type scanner[T any] struct {
+	cv Converter
+	p  *T
+}
+
+func (s scanner[T]) Scan(src any) error {
+	if src == nil {
+		var zero T
+		*s.p = zero
+		return nil
+	}
+
+	// drivers may reuse []byte values after Scan returns, so they aren't retained
+	if bs, ok := src.([]byte); ok {
+		src = append([]byte(nil), bs...)
+	}
+
+	dst := reflect.ValueOf(s.p).Elem()
+	err := setValue(s.cv, dst, reflect.ValueOf(src))
+	if bs, ok := src.([]byte); ok && err != nil {
+		if setValue(s.cv, dst, reflect.ValueOf(string(bs))) == nil {
+			return nil
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("conv: Scan: %w", err)
+	}
+	return nil
+}
+
+// Valuer returns a [driver.Valuer] converting v to a driver value with the [Converter].
+// Values of a driver value type are used as they are. Otherwise, the cheapest chain
+// of defined conversions to a driver value type is used, or else a conversion found
+// to int64, float64, bool, [time.Time], string, or []byte, in that order.
+// A nil pointer value is NULL.
+func Valuer[T any](cv Converter, v T) driver.Valuer {
+	return valuer[T]{cv, v}
+}
+
+type valuer[T any] struct {
+	cv Converter
+	v  T
+}
+
+func (vr valuer[T]) Value() (driver.Value, error) {
+	v := reflect.ValueOf(vr.v)
+	if !v.IsValid() || v.Kind() == reflect.Pointer && v.IsNil() {
+		return nil, nil
+	}
+	if driver.IsValue(vr.v) {
+		return vr.v, nil
+	}
+
+	register[T]()
+	convert := func(dt reflect.Type) (driver.Value, error) {
+		dst := reflect.New(dt).Elem()
+		if err := setValue(vr.cv, dst, v); err != nil {
+			return nil, fmt.Errorf("conv: Value: %w", err)
+		}
+		return dst.Interface(), nil
+	}
+
+	if path := vr.cv.search(lift.T[T](), isDriverType); path != nil {
+		return convert(lift.ReflectType(path[len(path)-1].dst))
+	}
+	m := mapper{cv: vr.cv}
+	for _, dt := range driverTypes {
+		if _, ok := m.resolve(v.Type(), dt); ok {
+			return convert(dt)
+		}
+	}
+	return nil, fmt.Errorf("conv: Value: %s to driver value: %w", v.Type(), ErrNotFound)
+}
+
+func isDriverType(t reflect.Type) bool {
+	for _, dt := range driverTypes {
+		if t == dt {
+			return true
+		}
+	}
+	return false
+}
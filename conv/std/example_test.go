@@ -0,0 +1,34 @@
+package std_test
+
+import (
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/AndrewHarrisSPU/lift/conv"
+	"github.com/AndrewHarrisSPU/lift/conv/std"
+)
+
+func ExampleRegister() {
+	cv := conv.NewConverter()
+	std.Register(cv)
+
+	n, _ := conv.To[int](cv, "42")
+	d, _ := conv.To[time.Duration](cv, "1h30m")
+	ip, _ := conv.To[net.IP](cv, "192.0.2.1")
+	h, _ := conv.To[std.Hex](cv, []byte("lift"))
+
+	fmt.Println(n, d, ip.To4() != nil, h)
+	// Output:
+	// 42 1h30m0s true 6c696674
+}
+
+func ExampleLayout() {
+	cv := conv.NewConverter()
+	std.Register(cv, std.Layout("2006-01-02"))
+
+	t, _ := conv.To[time.Time](cv, "2009-11-10")
+	fmt.Println(t.Weekday())
+	// Output:
+	// Tuesday
+}
@@ -0,0 +1,212 @@
+// The std package defines conversions between common standard library types and strings,
+// for a [conv.Converter].
+//
+// Register defines, in both directions:
+//   - string and bool, integer, floating point, and complex types, via strconv
+//   - string and [time.Time], with a configurable layout, and [time.Duration]
+//   - string and []byte; [Hex] and []byte; [Base64] and []byte
+//   - string and [net.IP], [url.URL], [*big.Int], and [*big.Float]
+//
+// Conversions to and from string are [conv.Entry.Direct], so they aren't chained:
+// with string as a hub, nearly every pair of these types would be connected,
+// and conversions like bool to int would be found, only to fail.
+// Conversions of [Hex] and [Base64] by way of []byte are chained.
+package std
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"net"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/AndrewHarrisSPU/lift/conv"
+)
+
+// Hex is a hexadecimal encoding of bytes.
+type Hex string
+
+// Base64 is a standard base64 encoding of bytes.
+type Base64 string
+
+type config struct {
+	layout string
+}
+
+// An Option configures conversions defined by [Register].
+type Option func(*config)
+
+// Layout sets the layout of [time.Time] conversions, as in [time.Parse].
+// The default is [time.RFC3339Nano].
+func Layout(layout string) Option {
+	return func(cfg *config) {
+		cfg.layout = layout
+	}
+}
+
+// Register defines standard conversions in a [conv.Converter].
+func Register(cv conv.Converter, opts ...Option) {
+	cfg := config{
+		layout: time.RFC3339Nano,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	cv.Store(direct(
+		// bool
+		conv.Def(strconv.ParseBool),
+		conv.DefInfallible(strconv.FormatBool),
+
+		// integers
+		conv.Def(parseInt[int](strconv.IntSize)),
+		conv.Def(parseInt[int8](8)),
+		conv.Def(parseInt[int16](16)),
+		conv.Def(parseInt[int32](32)),
+		conv.Def(parseInt[int64](64)),
+		conv.Def(parseUint[uint](strconv.IntSize)),
+		conv.Def(parseUint[uint8](8)),
+		conv.Def(parseUint[uint16](16)),
+		conv.Def(parseUint[uint32](32)),
+		conv.Def(parseUint[uint64](64)),
+		conv.Def(formatInt[int]),
+		conv.Def(formatInt[int8]),
+		conv.Def(formatInt[int16]),
+		conv.Def(formatInt[int32]),
+		conv.Def(formatInt[int64]),
+		conv.Def(formatUint[uint]),
+		conv.Def(formatUint[uint8]),
+		conv.Def(formatUint[uint16]),
+		conv.Def(formatUint[uint32]),
+		conv.Def(formatUint[uint64]),
+
+		// floating point and complex
+		conv.Def(func(s string) (float32, error) {
+			f, err := strconv.ParseFloat(s, 32)
+			return float32(f), err
+		}),
+		conv.Def(func(s string) (float64, error) {
+			return strconv.ParseFloat(s, 64)
+		}),
+		conv.DefInfallible(func(f float32) string {
+			return strconv.FormatFloat(float64(f), 'g', -1, 32)
+		}),
+		conv.DefInfallible(func(f float64) string {
+			return strconv.FormatFloat(f, 'g', -1, 64)
+		}),
+		conv.Def(func(s string) (complex64, error) {
+			c, err := strconv.ParseComplex(s, 64)
+			return complex64(c), err
+		}),
+		conv.Def(func(s string) (complex128, error) {
+			return strconv.ParseComplex(s, 128)
+		}),
+		conv.DefInfallible(func(c complex64) string {
+			return strconv.FormatComplex(complex128(c), 'g', -1, 64)
+		}),
+		conv.DefInfallible(func(c complex128) string {
+			return strconv.FormatComplex(c, 'g', -1, 128)
+		}),
+
+		// time
+		conv.Def(func(s string) (time.Time, error) {
+			return time.Parse(cfg.layout, s)
+		}),
+		conv.DefInfallible(func(t time.Time) string {
+			return t.Format(cfg.layout)
+		}),
+		conv.Def(time.ParseDuration),
+		conv.DefInfallible(time.Duration.String),
+
+		// bytes
+		conv.DefInfallible(func(s string) []byte {
+			return []byte(s)
+		}),
+		conv.DefInfallible(func(bs []byte) string {
+			return string(bs)
+		}),
+
+		// net
+		conv.Def(func(s string) (net.IP, error) {
+			if ip := net.ParseIP(s); ip != nil {
+				return ip, nil
+			}
+			return nil, fmt.Errorf("invalid IP address: %q", s)
+		}),
+		conv.DefInfallible(net.IP.String),
+		conv.Def(func(s string) (url.URL, error) {
+			u, err := url.Parse(s)
+			if err != nil {
+				return url.URL{}, err
+			}
+			return *u, nil
+		}),
+		conv.DefInfallible(func(u url.URL) string {
+			return u.String()
+		}),
+
+		// big
+		conv.Def(func(s string) (*big.Int, error) {
+			if n, ok := new(big.Int).SetString(s, 10); ok {
+				return n, nil
+			}
+			return nil, fmt.Errorf("invalid integer: %q", s)
+		}),
+		conv.DefInfallible((*big.Int).String),
+		conv.Def(func(s string) (*big.Float, error) {
+			f, _, err := big.ParseFloat(s, 10, 0, big.ToNearestEven)
+			return f, err
+		}),
+		conv.DefInfallible(func(f *big.Float) string {
+			return f.Text('g', -1)
+		}),
+	)...)
+
+	cv.Store(
+		conv.Def(func(h Hex) ([]byte, error) {
+			return hex.DecodeString(string(h))
+		}),
+		conv.DefInfallible(func(bs []byte) Hex {
+			return Hex(hex.EncodeToString(bs))
+		}),
+		conv.Def(func(b Base64) ([]byte, error) {
+			return base64.StdEncoding.DecodeString(string(b))
+		}),
+		conv.DefInfallible(func(bs []byte) Base64 {
+			return Base64(base64.StdEncoding.EncodeToString(bs))
+		}),
+	)
+}
+
+// direct marks entries as [conv.Entry.Direct].
+func direct(entries ...conv.Entry) []conv.Entry {
+	for i, e := range entries {
+		entries[i] = e.Direct()
+	}
+	return entries
+}
+
+func parseInt[T int | int8 | int16 | int32 | int64](bits int) func(string) (T, error) {
+	return func(s string) (T, error) {
+		n, err := strconv.ParseInt(s, 10, bits)
+		return T(n), err
+	}
+}
+
+func parseUint[T uint | uint8 | uint16 | uint32 | uint64](bits int) func(string) (T, error) {
+	return func(s string) (T, error) {
+		n, err := strconv.ParseUint(s, 10, bits)
+		return T(n), err
+	}
+}
+
+func formatInt[T int | int8 | int16 | int32 | int64](n T) (string, error) {
+	return strconv.FormatInt(int64(n), 10), nil
+}
+
+func formatUint[T uint | uint8 | uint16 | uint32 | uint64](n T) (string, error) {
+	return strconv.FormatUint(uint64(n), 10), nil
+}
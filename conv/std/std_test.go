@@ -0,0 +1,121 @@
+package std_test
+
+import (
+	"errors"
+	"math/big"
+	"net"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/AndrewHarrisSPU/lift"
+	"github.com/AndrewHarrisSPU/lift/conv"
+	"github.com/AndrewHarrisSPU/lift/conv/std"
+)
+
+// Ensure strings convert to each type and back
+func TestRoundTrip(t *testing.T) {
+	cv := conv.NewConverter()
+	std.Register(cv)
+
+	testRoundTrip[bool](t, cv, "false")
+	testRoundTrip[int](t, cv, "-42")
+	testRoundTrip[int8](t, cv, "-128")
+	testRoundTrip[int16](t, cv, "32767")
+	testRoundTrip[int32](t, cv, "-7")
+	testRoundTrip[int64](t, cv, "9223372036854775807")
+	testRoundTrip[uint](t, cv, "42")
+	testRoundTrip[uint8](t, cv, "255")
+	testRoundTrip[uint16](t, cv, "65535")
+	testRoundTrip[uint32](t, cv, "7")
+	testRoundTrip[uint64](t, cv, "18446744073709551615")
+	testRoundTrip[float32](t, cv, "1.5")
+	testRoundTrip[float64](t, cv, "0.1")
+	testRoundTrip[complex64](t, cv, "(1+2i)")
+	testRoundTrip[complex128](t, cv, "(1.5-2i)")
+	testRoundTrip[time.Time](t, cv, "2009-11-10T23:00:00Z")
+	testRoundTrip[time.Duration](t, cv, "1h2m3s")
+	testRoundTrip[[]byte](t, cv, "bytes")
+	testRoundTrip[net.IP](t, cv, "2001:db8::1")
+	testRoundTrip[url.URL](t, cv, "https://example.com/path?q=1")
+	testRoundTrip[*url.URL](t, cv, "https://example.com/")
+	testRoundTrip[*big.Int](t, cv, "123456789012345678901234567890")
+	testRoundTrip[*big.Float](t, cv, "1.25")
+}
+
+func testRoundTrip[T any](t *testing.T, cv conv.Converter, s string) {
+	t.Helper()
+	v, err := conv.To[T](cv, s)
+	if err != nil {
+		t.Errorf("%q: %v", s, err)
+		return
+	}
+	back, err := conv.To[string](cv, v)
+	if err != nil || back != s {
+		t.Errorf("%q: got %q, %v", s, back, err)
+	}
+}
+
+// Ensure malformed strings fail to convert
+func TestInvalid(t *testing.T) {
+	cv := conv.NewConverter()
+	std.Register(cv)
+
+	fails := []error{
+		errOf(conv.To[int8](cv, "128")),
+		errOf(conv.To[uint](cv, "-1")),
+		errOf(conv.To[bool](cv, "maybe")),
+		errOf(conv.To[time.Duration](cv, "soon")),
+		errOf(conv.To[net.IP](cv, "256.0.0.1")),
+		errOf(conv.To[*big.Int](cv, "1.5")),
+		errOf(conv.To[[]byte](cv, std.Hex("xyz"))),
+		errOf(conv.To[[]byte](cv, std.Base64("!!"))),
+	}
+	for i, err := range fails {
+		if err == nil {
+			t.Errorf("case %d: missing error", i)
+		}
+	}
+}
+
+func errOf[T any](_ T, err error) error {
+	return err
+}
+
+// Ensure bytes encode to hex and base64
+func TestEncodings(t *testing.T) {
+	cv := conv.NewConverter()
+	std.Register(cv)
+
+	data := []byte{0xde, 0xad, 0xbe, 0xef}
+	if h, _ := conv.To[std.Hex](cv, data); h != "deadbeef" {
+		t.Errorf("hex: got %s", h)
+	}
+	if b, _ := conv.To[std.Base64](cv, data); b != "3q2+7w==" {
+		t.Errorf("base64: got %s", b)
+	}
+}
+
+// Ensure conversions aren't chained by way of string
+func TestNoHubChains(t *testing.T) {
+	cv := conv.NewConverter()
+	std.Register(cv)
+
+	if _, err := conv.To[int](cv, true); !errors.Is(err, conv.ErrNotFound) {
+		t.Errorf("bool->int: %v", err)
+	}
+	if _, err := conv.To[int64](cv, time.Now()); !errors.Is(err, conv.ErrNotFound) {
+		t.Errorf("time.Time->int64: %v", err)
+	}
+	if _, err := conv.To[std.Hex](cv, "deadbeef"); !errors.Is(err, conv.ErrNotFound) {
+		t.Errorf("string->Hex: %v", err)
+	}
+	if err := cv.Require(conv.Pair{Src: lift.T[int](), Dst: lift.T[float64]()}); err == nil {
+		t.Errorf("int->float64: missing error")
+	}
+
+	// conversions by way of []byte are chained
+	if b, err := conv.To[std.Base64](cv, std.Hex("6c696674")); err != nil || b != "bGlmdA==" {
+		t.Errorf("Hex->Base64: %q, %v", b, err)
+	}
+}
@@ -0,0 +1,338 @@
+package conv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/AndrewHarrisSPU/lift"
+)
+
+// STRUCTS
+
+// ErrUnmapped is the cause of an [ElemError] when a [Strict] struct mapping leaves a field unmapped.
+var ErrUnmapped = errors.New("unmapped field")
+
+// A StructMode determines how [MapStruct] treats fields without a counterpart.
+type StructMode int
+
+const (
+	// Lenient leaves destination fields without a source field at their zero value,
+	// and ignores source fields without a destination field.
+	Lenient StructMode = iota
+	// Strict fails to map structs with fields lacking a counterpart, with [ErrUnmapped].
+	Strict
+)
+
+// WithStructMode sets the [StructMode] used by [MapStruct]. The default is [Lenient].
+func WithStructMode(m StructMode) Option {
+	return option(func(cfg *config) {
+		cfg.structs = m
+	})
+}
+
+// MapStruct maps the fields of src to the fields of the provided DST type.
+//
+// Exported fields are matched by name, or by the name given in a `conv:"name"` tag,
+// ignoring case.
+// Fields tagged `conv:"-"` are ignored. Each matched field is converted by the [Converter];
+// when no conversion is found, nested structs, slices, maps, and pointers are mapped field by field,
+// or element by element. A nil source, pointer, or slice leaves the destination field unset.
+//
+// Failures are reported as a [*BatchError], holding an [*ElemError] keyed by field path,
+// as in "Address.Lines[0]". The [Policy] of the Converter applies.
+func MapStruct[DST any, SRC any](cv Converter, src SRC) (dst DST, err error) {
+	m := mapper{cv: cv}
+	sv := reflect.ValueOf(src)
+	if cv.cfg.structs == Strict && sv.IsValid() {
+		m.unmapped("", reflect.TypeOf(dst), sv.Type(), map[[2]reflect.Type]bool{}, func(key string, isSrc bool) {
+			if isSrc {
+				m.fail(key, fmt.Errorf("%w: no destination", ErrUnmapped))
+			} else {
+				m.fail(key, fmt.Errorf("%w: no source", ErrUnmapped))
+			}
+		})
+		if len(m.batch.Errs) > 0 {
+			return dst, &m.batch
+		}
+	}
+
+	m.value("", reflect.ValueOf(&dst).Elem(), sv)
+	if len(m.batch.Errs) > 0 {
+		return dst, &m.batch
+	}
+	return dst, nil
+}
+
+// Unmapped returns the paths of fields without a counterpart, in mapping
+// from the provided SRC type to the provided DST type with [MapStruct].
+func Unmapped[DST any, SRC any](cv Converter) (src, dst []string) {
+	m := mapper{cv: cv}
+	m.unmapped("", lift.ReflectType(lift.T[DST]()), lift.ReflectType(lift.T[SRC]()), map[[2]reflect.Type]bool{}, func(key string, isSrc bool) {
+		if isSrc {
+			src = append(src, key)
+		} else {
+			dst = append(dst, key)
+		}
+	})
+	return src, dst
+}
+
+// A mapper maps values field by field, collecting failures.
+type mapper struct {
+	cv    Converter
+	batch BatchError
+}
+
+func (m *mapper) fail(key string, err error) {
+	m.batch.Errs = append(m.batch.Errs, &ElemError{Key: key, Err: err})
+}
+
+func (m *mapper) done() bool {
+	return m.cv.cfg.policy == FailFast && len(m.batch.Errs) > 0
+}
+
+// value maps src to dst. An invalid src, as from a nil interface, leaves dst unset.
+func (m *mapper) value(path string, dst, src reflect.Value) {
+	if !src.IsValid() {
+		return
+	}
+	st, dt := src.Type(), dst.Type()
+	switch {
+	case st == dt:
+		dst.Set(src)
+		return
+	case m.convert(path, dst, src):
+		return
+	}
+
+	switch {
+	case st.Kind() == reflect.Interface || st.Kind() == reflect.Pointer:
+		if !src.IsNil() {
+			m.value(path, dst, src.Elem())
+		}
+	case dt.Kind() == reflect.Pointer:
+		// the pointer is set unless the pointee itself failed to convert
+		p := reflect.New(dt.Elem())
+		n := len(m.batch.Errs)
+		m.value(path, p.Elem(), src)
+		for _, err := range m.batch.Errs[n:] {
+			if err.Key == path {
+				return
+			}
+		}
+		dst.Set(p)
+	case st.Kind() == reflect.Struct && dt.Kind() == reflect.Struct:
+		df, sf := fields(dt), fields(st)
+		for _, d := range df {
+			s, ok := sf[d.key]
+			if !ok {
+				continue
+			}
+			m.value(join(path, d.name), dst.FieldByIndex(d.index), src.FieldByIndex(s.index))
+			if m.done() {
+				return
+			}
+		}
+	case st.Kind() == reflect.Map && st.Key().Kind() == reflect.String && dt.Kind() == reflect.Struct:
+		m.decode(path, dst, src)
+	case st.Kind() == reflect.Map && dt.Kind() == reflect.Map:
+		m.mapValues(path, dst, src)
+	case st.Kind() == reflect.Slice && dt.Kind() == reflect.Slice:
+		if src.IsNil() {
+			return
+		}
+		dst.Set(reflect.MakeSlice(dt, src.Len(), src.Len()))
+		for i := 0; i < src.Len(); i++ {
+			m.value(path+"["+strconv.Itoa(i)+"]", dst.Index(i), src.Index(i))
+			if m.done() {
+				return
+			}
+		}
+	default:
+		m.fail(path, fmt.Errorf("conv: %s->%s: %w", st, dt, ErrNotFound))
+	}
+}
+
+// mapValues maps a map to a map, key by key and value by value, in order of formatted key.
+// Entries that fail to convert are left out. As in [ToMap], when source keys collide,
+// the first is converted, and others fail with [ErrDuplicateKey].
+func (m *mapper) mapValues(path string, dst, src reflect.Value) {
+	if src.IsNil() {
+		return
+	}
+	dt := dst.Type()
+	dst.Set(reflect.MakeMapWithSize(dt, src.Len()))
+
+	keys := src.MapKeys()
+	sort.Slice(keys, func(i, j int) bool {
+		return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j])
+	})
+
+	for _, k := range keys {
+		key := path + "[" + fmt.Sprint(k) + "]"
+		n := len(m.batch.Errs)
+		dk, dv := reflect.New(dt.Key()).Elem(), reflect.New(dt.Elem()).Elem()
+		m.value(key, dk, k)
+		if len(m.batch.Errs) == n {
+			m.value(key, dv, src.MapIndex(k))
+		}
+		switch {
+		case len(m.batch.Errs) > n:
+		case dst.MapIndex(dk).IsValid():
+			m.fail(key, fmt.Errorf("%w: %v", ErrDuplicateKey, dk))
+		default:
+			dst.SetMapIndex(dk, dv)
+		}
+		if m.done() {
+			return
+		}
+	}
+}
+
+// convert maps src to dst with a conversion resolved by the [Converter], if found.
+func (m *mapper) convert(path string, dst, src reflect.Value) bool {
+	convFunc, ok := m.resolve(src.Type(), dst.Type())
+	if !ok {
+		return false
+	}
+	sym, _ := lift.WrapValue(src)
+	out, err := convFunc(context.Background(), sym)
+	if err != nil {
+		m.fail(path, err)
+		return true
+	}
+	v, _ := lift.ReflectValue(out)
+	dst.Set(v)
+	return true
+}
+
+// resolve resolves a conversion between types recorded by [lift.Register].
+func (m *mapper) resolve(st, dt reflect.Type) (symFunc, bool) {
+	src, ok := flavorOf(st)
+	if !ok {
+		return nil, false
+	}
+	dst, ok := flavorOf(dt)
+	if !ok {
+		return nil, false
+	}
+	convFunc, ok := m.cv.resolve(src, dst)
+	if !ok {
+		return nil, false
+	}
+	return m.cv.intercept(src, dst, convFunc), true
+}
+
+// unmapped reports fields without a counterpart, following the type structure value maps.
+// Source fields are reported with isSrc set.
+func (m *mapper) unmapped(path string, dt, st reflect.Type, seen map[[2]reflect.Type]bool, report func(key string, isSrc bool)) {
+	if st == dt || seen[[2]reflect.Type{dt, st}] {
+		return
+	}
+	seen[[2]reflect.Type{dt, st}] = true
+	if _, ok := m.resolve(st, dt); ok {
+		return
+	}
+
+	switch {
+	case st.Kind() == reflect.Pointer:
+		m.unmapped(path, dt, st.Elem(), seen, report)
+	case dt.Kind() == reflect.Pointer:
+		m.unmapped(path, dt.Elem(), st, seen, report)
+	case st.Kind() == reflect.Struct && dt.Kind() == reflect.Struct:
+		df, sf := fields(dt), fields(st)
+		for _, d := range sortedFields(df) {
+			if s, ok := sf[d.key]; ok {
+				m.unmapped(join(path, d.name), d.typ, s.typ, seen, report)
+			} else {
+				report(join(path, d.name), false)
+			}
+		}
+		for _, s := range sortedFields(sf) {
+			if _, ok := df[s.key]; !ok {
+				report(join(path, s.name), true)
+			}
+		}
+	case st.Kind() == reflect.Slice && dt.Kind() == reflect.Slice,
+		st.Kind() == reflect.Map && dt.Kind() == reflect.Map:
+		m.unmapped(path+"[]", dt.Elem(), st.Elem(), seen, report)
+	}
+}
+
+// A field is an exported struct field, keyed by name or tag.
+type field struct {
+	key, name string
+	index     []int
+	typ       reflect.Type
+
+	// tag options, observed by [Decode], [Flags], and [LoadEnv]
+	required   bool
+	hasDefault bool
+	dflt       string
+	usage      string
+}
+
+// fields returns the mapped fields of a struct type, by lower-cased key.
+func fields(t reflect.Type) map[string]field {
+	fs := make(map[string]field)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		fd := field{key: f.Name, name: f.Name, index: f.Index, typ: f.Type, usage: f.Tag.Get("usage")}
+		if tag, ok := f.Tag.Lookup("conv"); ok {
+			if tag == "-" {
+				continue
+			}
+			fd.parseTag(tag)
+		}
+		fd.key = strings.ToLower(fd.key)
+		fs[fd.key] = fd
+	}
+	return fs
+}
+
+// parseTag reads a `conv:"name,required,default=value"` tag. Options are optional,
+// and the default value, extending to the end of the tag, may contain commas.
+func (f *field) parseTag(tag string) {
+	name, opts, _ := strings.Cut(tag, ",")
+	if name != "" {
+		f.key = name
+	}
+	for opts != "" {
+		if strings.HasPrefix(opts, "default=") {
+			f.hasDefault, f.dflt = true, strings.TrimPrefix(opts, "default=")
+			return
+		}
+		var opt string
+		opt, opts, _ = strings.Cut(opts, ",")
+		if opt == "required" {
+			f.required = true
+		}
+	}
+}
+
+// sortedFields orders fields by position in the struct.
+func sortedFields(fs map[string]field) []field {
+	sorted := make([]field, 0, len(fs))
+	for _, f := range fs {
+		sorted = append(sorted, f)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].index[0] < sorted[j].index[0]
+	})
+	return sorted
+}
+
+func join(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
@@ -0,0 +1,137 @@
+package conv
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing/quick"
+
+	"github.com/AndrewHarrisSPU/lift"
+)
+
+// ROUND TRIPS
+
+// Entries is a list of entries. Entries is an [Option], defining each conversion.
+type Entries []Entry
+
+func (es Entries) apply(cv Converter) {
+	cv.Store(es...)
+}
+
+// DefPair wraps a pair of inverse conversion functions, yielding an [Entry] for each direction.
+// The pair may be checked with [Verify].
+func DefPair[A any, B any](fwd func(A) (B, error), back func(B) (A, error)) Entries {
+	return Entries{Def(fwd), Def(back)}
+}
+
+// A Loss reports a value that didn't survive a round trip, from Src to Dst and back.
+// Either Out differs from In, or converting back failed with Err.
+type Loss struct {
+	Pair
+	In, Out lift.Sym
+	Err     error
+}
+
+func (l Loss) String() string {
+	in, _ := lift.ReflectValue(l.In)
+	if l.Err != nil {
+		return fmt.Sprintf("%s: %v: %v", l.Pair, in, l.Err)
+	}
+	out, _ := lift.ReflectValue(l.Out)
+	return fmt.Sprintf("%s: %v became %v", l.Pair, in, out)
+}
+
+// corpusSize is the number of values generated for each type, when [Verify] isn't provided samples.
+const corpusSize = 100
+
+// Verify checks round trips of defined conversions, for each pair of types
+// with conversions defined in both directions, and reports values that don't survive.
+// Values are compared with [reflect.DeepEqual].
+//
+// Round trips are checked for samples of matching type. Values failing to convert
+// in the first direction are skipped. Without samples, round trips are checked
+// for values generated by [quick.Value], where possible.
+func Verify(cv Converter, samples ...any) []Loss {
+	corpus := make(map[reflect.Type][]lift.Sym)
+	for _, s := range samples {
+		if sym, ok := lift.WrapValue(reflect.ValueOf(s)); ok {
+			t := lift.ReflectType(sym)
+			corpus[t] = append(corpus[t], sym)
+		}
+	}
+
+	var losses []Loss
+	for _, src := range cv.srcs() {
+		for _, fwd := range cv.from(src) {
+			back, ok := inverse(cv, fwd)
+			if !ok {
+				continue
+			}
+
+			syms := corpus[lift.ReflectType(src)]
+			if len(samples) == 0 {
+				syms = generate(lift.ReflectType(src))
+			}
+			for _, in := range syms {
+				if loss, ok := roundTrip(fwd, back, in); !ok {
+					losses = append(losses, loss)
+				}
+			}
+		}
+	}
+	return losses
+}
+
+// inverse finds the entry converting back to the source type of e.
+func inverse(cv Converter, e Entry) (Entry, bool) {
+	for _, back := range cv.from(e.dst) {
+		if lift.ReflectType(back.dst) == lift.ReflectType(e.src) {
+			return back, true
+		}
+	}
+	return Entry{}, false
+}
+
+// roundTrip converts in by fwd and back, reporting a [Loss] if the result differs.
+func roundTrip(fwd, back Entry, in lift.Sym) (Loss, bool) {
+	ctx := context.Background()
+	mid, err := fwd.call(ctx, in)
+	if err != nil {
+		return Loss{}, true
+	}
+
+	loss := Loss{Pair: Pair{fwd.src, fwd.dst}, In: in}
+	if loss.Out, loss.Err = back.call(ctx, mid); loss.Err != nil {
+		return loss, false
+	}
+
+	a, _ := lift.ReflectValue(in)
+	b, _ := lift.ReflectValue(loss.Out)
+	return loss, reflect.DeepEqual(a.Interface(), b.Interface())
+}
+
+// generate returns wrapped values of type t, generated with a fixed seed.
+// Types [quick.Value] can't generate, or lift can't wrap, yield no values.
+func generate(t reflect.Type) (syms []lift.Sym) {
+	defer func() {
+		// quick.Value panics on unexported struct fields
+		if recover() != nil {
+			syms = nil
+		}
+	}()
+
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < corpusSize; i++ {
+		v, ok := quick.Value(t, rnd)
+		if !ok {
+			return nil
+		}
+		sym, ok := lift.WrapValue(v)
+		if !ok {
+			return nil
+		}
+		syms = append(syms, sym)
+	}
+	return syms
+}
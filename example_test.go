@@ -48,6 +48,13 @@ func ExampleEnumIs() {
 	// true
 }
 
+func ExampleFlavor() {
+	sym := lift.Wrap(3.14)
+	fmt.Println(lift.Flavor(sym) == lift.T[float64]())
+	// Output:
+	// true
+}
+
 // WRAP, UNWRAP
 
 func ExampleWrap() {
@@ -212,7 +219,7 @@ func ExampleLoad() {
 	// oops
 }
 
-func ExampleLoadKey() {
+func ExampleLoadTypeOf_names() {
 	names := lift.NewMap[string](
 		lift.Def[bool]("Boolean"),
 		lift.Def[string]("string"),
@@ -258,6 +265,163 @@ func ExampleLoadTypeOf() {
 	// 0000007f
 }
 
+// UNION
+
+func ExampleUnion2() {
+	type ping struct{ seq int }
+	type pong struct{ seq int }
+
+	msgs := []lift.Sym{
+		lift.Wrap(ping{1}),
+		lift.Wrap(pong{1}),
+		lift.Wrap("not a message"),
+	}
+
+	for _, msg := range msgs {
+		u, ok := lift.NewUnion2[ping, pong](msg)
+		if !ok {
+			fmt.Println("rejected")
+			continue
+		}
+		u.Switch(
+			func(p ping) { fmt.Println("ping", p.seq) },
+			func(p pong) { fmt.Println("pong", p.seq) },
+		)
+	}
+	// Output:
+	// ping 1
+	// pong 1
+	// rejected
+}
+
+func ExampleUnion3_Alternatives() {
+	var u lift.Union3[int, string, bool]
+
+	for _, sym := range u.Alternatives() {
+		fmt.Println(sym == lift.T[string]())
+	}
+	// Output:
+	// false
+	// true
+	// false
+}
+
+// LIFTING
+
+func ExampleLift() {
+	double := lift.Lift(func(n int) int {
+		return 2 * n
+	})
+
+	n, ok := double(lift.Wrap(21))
+	fmt.Println(n, ok)
+
+	n, ok = double(lift.Wrap("21"))
+	fmt.Println(n, ok)
+	// Output:
+	// 42 true
+	// 0 false
+}
+
+func ExampleLiftErr() {
+	half := lift.LiftErr(func(n int) (int, error) {
+		if n%2 != 0 {
+			return 0, fmt.Errorf("odd")
+		}
+		return n / 2, nil
+	})
+
+	for _, sym := range []lift.Sym{lift.Wrap(4), lift.Wrap(5), lift.Wrap(4.0)} {
+		if n, err := half(sym); err != nil {
+			fmt.Println(err)
+		} else {
+			fmt.Println(n)
+		}
+	}
+	// Output:
+	// 2
+	// odd
+	// LiftErr: want lift.enum[int], got lift.enum[float64]
+}
+
+func ExampleLiftSym() {
+	length := lift.LiftSym(func(s string) int {
+		return len(s)
+	})
+
+	n := length(lift.Wrap("four"))
+	fmt.Println(lift.EnumIs[int](n), lift.MustUnwrap[int](n))
+	// Output:
+	// true 4
+}
+
+func ExampleLift2() {
+	repeat := lift.Lift2(strings.Repeat)
+
+	s, _ := repeat(lift.Wrap2("ab", 3))
+	fmt.Println(s)
+
+	// a Tuple2[int, string] is a different flavor than a Tuple2[string, int]
+	_, ok := repeat(lift.Wrap2(3, "ab"))
+	fmt.Println(ok)
+	// Output:
+	// ababab
+	// false
+}
+
+func ExampleCompose() {
+	shout := lift.Compose(strings.TrimSpace, strings.ToUpper)
+	fmt.Println(shout("  hey "))
+	// Output:
+	// HEY
+}
+
+func ExampleCurry() {
+	prefix := lift.Curry(func(pre, s string) string {
+		return pre + s
+	})
+
+	un := prefix("un")
+	fmt.Println(un("lifted"), un("wrapped"))
+	// Output:
+	// unlifted unwrapped
+}
+
+// REFLECTION
+
+func ExampleCall() {
+	type greeting string
+
+	// heterogeneous callables, stored in one Map
+	greet := func(g greeting, name string) string {
+		return fmt.Sprintf("%s, %s!", g, name)
+	}
+	callables := lift.NewMap[lift.Sym](
+		lift.Def[greeting](lift.Wrap(greet)),
+		lift.Def[int](lift.Wrap(strings.Repeat)),
+	)
+
+	fn, _ := lift.Load[greeting](callables)
+	out, _ := lift.Call(fn, lift.Wrap(greeting("Hello")), lift.Wrap("gopher"))
+	fmt.Println(lift.MustUnwrap[string](out[0]))
+
+	fn, _ = lift.Load[int](callables)
+	if _, err := lift.Call(fn, lift.Wrap("ab"), lift.Wrap("3")); err != nil {
+		fmt.Println(err)
+	}
+	// Output:
+	// Hello, gopher!
+	// Call: func(string, int) string: argument 1: want int, got string
+}
+
+func ExampleReflectType() {
+	fmt.Println(lift.ReflectType(lift.T[[]rune]()))
+	fmt.Println(lift.ReflectType(lift.Wrap(any(0))))
+	// Output:
+	// []int32
+	// interface {}
+}
+
 // Corner cases of [Sym] around empty-ish or any-ish values are reasonable.
 // [Sym] is an interface type, so the zero value of a [Sym] is nil-ish, and will cause panic.
 func Example_e_emptyAnyNil() {
@@ -0,0 +1,101 @@
+package fsm
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/AndrewHarrisSPU/lift"
+)
+
+// DOT
+
+// WriteDOT writes a Graphviz DOT description of a [Machine].
+// Edges are labeled by input flavor; guarded edges are marked with a trailing '?'.
+// States with children are drawn as clusters, and parallel states as dashed clusters.
+func (m *Machine) WriteDOT(w io.Writer) error {
+	var b strings.Builder
+	b.WriteString("digraph fsm {\n")
+	b.WriteString("\tcompound=true;\n")
+	b.WriteString("\trankdir=LR;\n")
+	b.WriteString("\tnode [shape=circle];\n")
+	b.WriteString("\t\"\" [shape=point];\n")
+	fmt.Fprintf(&b, "\t\"\" -> %q%s;\n", anchor(m.initial).name, edgeAttrs(nil, m.initial, ""))
+
+	for _, s := range m.order {
+		if s.parent == nil {
+			writeState(&b, s, "\t")
+		}
+	}
+
+	for _, s := range m.order {
+		for _, input := range s.inputs {
+			ts, _ := lift.LoadSym(s.edges, input)
+			for _, t := range ts {
+				label := lift.ReflectType(input).String()
+				if t.guard != nil {
+					label += "?"
+				}
+				fmt.Fprintf(&b, "\t%q -> %q%s;\n", anchor(t.from).name, anchor(t.to).name, edgeAttrs(t.from, t.to, label))
+			}
+		}
+	}
+	b.WriteString("}\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writeState(b *strings.Builder, s *state, indent string) {
+	if s.atomic() {
+		switch {
+		case s.history == Shallow:
+			fmt.Fprintf(b, "%s%q [label=\"H\"];\n", indent, s.name)
+		case s.history == Deep:
+			fmt.Fprintf(b, "%s%q [label=\"H*\"];\n", indent, s.name)
+		case s.failed:
+			fmt.Fprintf(b, "%s%q [shape=octagon];\n", indent, s.name)
+		case s.final:
+			fmt.Fprintf(b, "%s%q [shape=doublecircle];\n", indent, s.name)
+		default:
+			fmt.Fprintf(b, "%s%q;\n", indent, s.name)
+		}
+		return
+	}
+
+	fmt.Fprintf(b, "%ssubgraph %q {\n", indent, "cluster_"+s.name)
+	fmt.Fprintf(b, "%s\tlabel=%q;\n", indent, s.name)
+	if s.parallel {
+		fmt.Fprintf(b, "%s\tstyle=dashed;\n", indent)
+	}
+	for _, c := range s.children {
+		writeState(b, c, indent+"\t")
+	}
+	fmt.Fprintf(b, "%s}\n", indent)
+}
+
+// anchor returns the state drawn as the endpoint of edges to or from s.
+func anchor(s *state) *state {
+	for !s.atomic() {
+		s = s.defaultChild()
+	}
+	return s
+}
+
+// edgeAttrs formats edge attributes. Edges to or from states drawn as clusters are clipped to the cluster.
+func edgeAttrs(from, to *state, label string) string {
+	var attrs []string
+	if label != "" {
+		attrs = append(attrs, fmt.Sprintf("label=%q", label))
+	}
+	if from != nil && !from.atomic() {
+		attrs = append(attrs, fmt.Sprintf("ltail=%q", "cluster_"+from.name))
+	}
+	if !to.atomic() {
+		attrs = append(attrs, fmt.Sprintf("lhead=%q", "cluster_"+to.name))
+	}
+	if len(attrs) == 0 {
+		return ""
+	}
+	return " [" + strings.Join(attrs, ", ") + "]"
+}
@@ -0,0 +1,160 @@
+package fsm_test
+
+import (
+	"fmt"
+
+	"github.com/AndrewHarrisSPU/lift"
+	"github.com/AndrewHarrisSPU/lift/fsm"
+)
+
+// This example emulates a pocket calculator, like the calculator example of the lift package.
+// Clearing and evaluating are handled by a parent state, "on", rather than by every state.
+// Division by zero fails an [fsm.Action], so the calculator enters its error state.
+func Example_calculator() {
+	c := newCalculator()
+
+	for _, r := range "1+2*3=-4=C/=-5C-56=7+8=9=" {
+		c.Fire(parseKey(r))
+	}
+	// Output:
+	// 1+
+	// >        1
+	// 2*
+	// >        3
+	// 3=
+	// >        9
+	// -4=
+	// >        5
+	// C/
+	// >        0
+	// =
+	// > DIVZERO!
+	// -5C-
+	// >        0
+	// 56=
+	// >      -56
+	// 7+
+	// >        7
+	// 8=
+	// >       15
+	// 9=
+	// >        9
+}
+
+// PARSING
+
+type keyClear struct{}
+type keyEquals struct{}
+type keyOp rune
+type keyDigit int
+
+func parseKey(r rune) lift.Sym {
+	fmt.Printf("%c", r)
+
+	switch r {
+	case '=':
+		return lift.Wrap(keyEquals{})
+	case '+', '-', '*', '/':
+		return lift.Wrap(keyOp(r))
+	case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+		return lift.Wrap(keyDigit(r - '0'))
+	default:
+		return lift.Wrap(keyClear{})
+	}
+}
+
+// CALCULATOR
+
+type calculator struct {
+	*fsm.Machine
+	acc, res int
+	op       keyOp
+}
+
+func newCalculator() *calculator {
+	c := &calculator{op: '+'}
+
+	nonzero := func(in lift.Sym) bool {
+		return lift.MustUnwrap[keyDigit](in) != 0
+	}
+
+	m, err := fsm.New("start").
+		State("on").
+		State("start", fsm.In("on")).
+		State("accumulate", fsm.In("on")).
+		State("evaluated", fsm.In("on")).
+		Error("err", fsm.In("on")).
+		Inputs(lift.T[keyClear](), lift.T[keyEquals](), lift.T[keyOp](), lift.T[keyDigit]()).
+		// shared edges
+		On("on", lift.T[keyClear](), "start", fsm.Do(c.reset)).
+		On("on", lift.T[keyEquals](), "evaluated", fsm.Do(c.evaluate)).
+		// start
+		On("start", lift.T[keyOp](), "start", fsm.Do(c.evaluate), fsm.Do(c.store)).
+		On("start", lift.T[keyDigit](), "accumulate", fsm.When(nonzero), fsm.Do(c.begin)).
+		On("start", lift.T[keyDigit](), "start").
+		// accumulate
+		On("accumulate", lift.T[keyOp](), "start", fsm.Do(c.evaluate), fsm.Do(c.store)).
+		On("accumulate", lift.T[keyDigit](), "accumulate", fsm.Do(c.accumulate)).
+		// evaluated
+		On("evaluated", lift.T[keyOp](), "start", fsm.Do(c.store)).
+		On("evaluated", lift.T[keyDigit](), "accumulate", fsm.When(nonzero), fsm.Do(c.restart)).
+		On("evaluated", lift.T[keyDigit](), "start", fsm.Do(c.reset)).
+		// err
+		On("err", lift.T[keyOp](), "err").
+		On("err", lift.T[keyDigit](), "err").
+		Build()
+	if err != nil {
+		panic(err)
+	}
+
+	c.Machine = m
+	return c
+}
+
+// ACTIONS
+
+func (c *calculator) reset(lift.Sym) error {
+	c.acc, c.res = 0, 0
+	c.op = '+'
+	return nil
+}
+
+func (c *calculator) store(in lift.Sym) error {
+	c.op = lift.MustUnwrap[keyOp](in)
+	return nil
+}
+
+func (c *calculator) begin(in lift.Sym) error {
+	c.acc = 0
+	return c.accumulate(in)
+}
+
+func (c *calculator) restart(in lift.Sym) error {
+	c.reset(in)
+	return c.begin(in)
+}
+
+func (c *calculator) accumulate(in lift.Sym) error {
+	c.acc = 10*c.acc + int(lift.MustUnwrap[keyDigit](in))
+	return nil
+}
+
+func (c *calculator) evaluate(lift.Sym) error {
+	fmt.Print("\n> ")
+	switch c.op {
+	case '+':
+		c.res += c.acc
+	case '-':
+		c.res -= c.acc
+	case '*':
+		c.res *= c.acc
+	case '/':
+		if c.acc == 0 {
+			fmt.Println("DIVZERO!")
+			return fmt.Errorf("DIVZERO!")
+		}
+		c.res /= c.acc
+	}
+	fmt.Printf("%8d\n", c.res)
+	return nil
+}
@@ -0,0 +1,180 @@
+package fsm_test
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/AndrewHarrisSPU/lift"
+	"github.com/AndrewHarrisSPU/lift/fsm"
+)
+
+type coin int
+type push struct{}
+
+func Example_turnstile() {
+	var credit int
+
+	m, err := fsm.New("locked").
+		State("locked").
+		State("unlocked", fsm.OnEntry(func(lift.Sym) error {
+			fmt.Println("click")
+			return nil
+		})).
+		Inputs(lift.T[coin](), lift.T[push]()).
+		On("locked", lift.T[coin](), "unlocked",
+			fsm.When(func(in lift.Sym) bool {
+				return credit+int(lift.MustUnwrap[coin](in)) >= 25
+			}),
+			fsm.Do(func(lift.Sym) error {
+				credit = 0
+				return nil
+			})).
+		On("locked", lift.T[coin](), "locked",
+			fsm.Do(func(in lift.Sym) error {
+				credit += int(lift.MustUnwrap[coin](in))
+				return nil
+			})).
+		On("locked", lift.T[push](), "locked").
+		On("unlocked", lift.T[coin](), "unlocked").
+		On("unlocked", lift.T[push](), "locked").
+		Build()
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	inputs := []lift.Sym{
+		lift.Wrap(push{}),
+		lift.Wrap(coin(10)),
+		lift.Wrap(coin(10)),
+		lift.Wrap(coin(5)),
+		lift.Wrap(push{}),
+		lift.Wrap("kick"),
+	}
+	for _, in := range inputs {
+		handled := m.Fire(in)
+		fmt.Println(m.State(), handled)
+	}
+	// Output:
+	// locked true
+	// locked true
+	// locked true
+	// click
+	// unlocked true
+	// locked true
+	// locked false
+}
+
+func ExampleBuilder_Build() {
+	_, err := fsm.New("idle").
+		State("idle").
+		State("busy").
+		State("lost").
+		Inputs(lift.T[push]()).
+		On("idle", lift.T[push](), "busy").
+		Build()
+
+	fmt.Println(err)
+	// Output:
+	// fsm: unreachable state "lost"; state "busy": unhandled input fsm_test.push; state "lost": unhandled input fsm_test.push
+}
+
+func ExampleBuilder_Error() {
+	m, _ := fsm.New("ok").
+		State("ok").
+		Error("broken").
+		On("ok", lift.T[coin](), "ok",
+			fsm.Do(func(in lift.Sym) error {
+				if lift.MustUnwrap[coin](in) < 0 {
+					return fmt.Errorf("counterfeit coin")
+				}
+				return nil
+			})).
+		Build()
+
+	m.Fire(lift.Wrap(coin(-1)))
+	fmt.Println(m.State(), m.Err())
+	// Output:
+	// broken counterfeit coin
+}
+
+func ExampleMachine_WriteDOT() {
+	m, _ := fsm.New("off").
+		State("off").
+		State("on").
+		On("off", lift.T[push](), "on").
+		On("on", lift.T[push](), "off").
+		Build()
+
+	m.WriteDOT(os.Stdout)
+	// Output:
+	// digraph fsm {
+	// 	compound=true;
+	// 	rankdir=LR;
+	// 	node [shape=circle];
+	// 	"" [shape=point];
+	// 	"" -> "off";
+	// 	"off";
+	// 	"on";
+	// 	"off" -> "on" [label="fsm_test.push"];
+	// 	"on" -> "off" [label="fsm_test.push"];
+	// }
+}
+
+type next struct{}
+type pause struct{}
+
+func ExampleBuilder_History() {
+	m, _ := fsm.New("player").
+		State("player").
+		State("intro", fsm.In("player")).
+		State("feature", fsm.In("player")).
+		State("credits", fsm.In("player")).
+		History("resume", "player", fsm.Shallow).
+		State("paused").
+		On("intro", lift.T[next](), "feature").
+		On("feature", lift.T[next](), "credits").
+		On("player", lift.T[pause](), "paused").
+		On("paused", lift.T[pause](), "resume").
+		Build()
+
+	for _, in := range []lift.Sym{
+		lift.Wrap(next{}),
+		lift.Wrap(pause{}),
+		lift.Wrap(pause{}),
+	} {
+		m.Fire(in)
+		fmt.Println(m.Active())
+	}
+	// Output:
+	// [player feature]
+	// [paused]
+	// [player feature]
+}
+
+type tick struct{}
+type toggle struct{}
+
+func ExampleParallel() {
+	m, _ := fsm.New("panel").
+		State("panel", fsm.Parallel()).
+		State("clock", fsm.In("panel")).
+		State("am", fsm.In("clock")).
+		State("pm", fsm.In("clock")).
+		State("light", fsm.In("panel")).
+		State("dark", fsm.In("light")).
+		State("lit", fsm.In("light")).
+		On("am", lift.T[tick](), "pm").
+		On("pm", lift.T[tick](), "am").
+		On("dark", lift.T[toggle](), "lit").
+		On("lit", lift.T[toggle](), "dark").
+		Build()
+
+	fmt.Println(m.Active())
+	m.Fire(lift.Wrap(tick{}))
+	m.Fire(lift.Wrap(toggle{}))
+	fmt.Println(m.Active())
+	// Output:
+	// [panel clock am light dark]
+	// [panel clock pm light lit]
+}
@@ -0,0 +1,715 @@
+// The fsm package provides a finite state [Machine], with transitions keyed by input flavor.
+//
+// A [Machine] is declared with a [Builder]. Inputs are [lift.Sym], and a transition
+// is selected by the type enumeration of an input, as in [lift.LoadSym].
+// Declarations are validated when the [Machine] is built.
+//
+// States may be nested, with [In]. An input unhandled by a state bubbles to its parent.
+// Entering a state with children enters its initial child, or every child of a [Parallel] state.
+// History pseudo-states, declared by [Builder.History], restore previously active children.
+package fsm
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/AndrewHarrisSPU/lift"
+)
+
+// A State names a state of a [Machine].
+type State string
+
+// An Action runs when a transition fires, or when a state is entered or exited.
+// The argument is the input that triggered the action.
+// Failing actions move a [Machine] to its error state.
+type Action func(in lift.Sym) error
+
+// A Guard permits or vetoes a transition.
+type Guard func(in lift.Sym) bool
+
+// A HistoryKind distinguishes shallow and deep history pseudo-states.
+type HistoryKind int
+
+const (
+	// Shallow history restores the previously active children of a state.
+	Shallow HistoryKind = iota + 1
+	// Deep history restores the previously active descendants of a state.
+	Deep
+)
+
+type state struct {
+	name          State
+	index         int
+	parentName    State
+	parent        *state
+	children      []*state
+	initial       State
+	parallel      bool
+	history       HistoryKind
+	entry, exit   Action
+	final, failed bool
+	edges         lift.Map[[]*transition]
+	inputs        []lift.Sym
+}
+
+type transition struct {
+	input    lift.Sym
+	from, to *state
+	guard    Guard
+	action   Action
+}
+
+// BUILDER
+
+// A Builder declares the states and transitions of a [Machine].
+type Builder struct {
+	initial  State
+	failed   State
+	states   map[State]*state
+	order    []*state
+	inputs   []lift.Sym
+	problems []string
+}
+
+// New returns a [Builder] for a [Machine] starting in the initial state.
+func New(initial State) *Builder {
+	return &Builder{
+		initial: initial,
+		states:  make(map[State]*state),
+	}
+}
+
+// A StateOption configures a state declared by [Builder.State].
+type StateOption func(*state)
+
+// OnEntry sets an [Action] run when entering a state.
+func OnEntry(fn Action) StateOption {
+	return func(s *state) {
+		s.entry = fn
+	}
+}
+
+// OnExit sets an [Action] run when exiting a state.
+func OnExit(fn Action) StateOption {
+	return func(s *state) {
+		s.exit = fn
+	}
+}
+
+// Final marks a state as final. Final states are not required to handle inputs declared by [Builder.Inputs].
+func Final() StateOption {
+	return func(s *state) {
+		s.final = true
+	}
+}
+
+// In nests a state within a previously declared parent state.
+func In(parent State) StateOption {
+	return func(s *state) {
+		s.parentName = parent
+	}
+}
+
+// Initial sets the child entered by default when entering a state.
+// Without Initial, the first declared child is entered.
+func Initial(child State) StateOption {
+	return func(s *state) {
+		s.initial = child
+	}
+}
+
+// Parallel marks a state as having orthogonal regions. Each child of a parallel state
+// is a region, and entering a parallel state enters every region.
+func Parallel() StateOption {
+	return func(s *state) {
+		s.parallel = true
+	}
+}
+
+// State declares a state.
+func (b *Builder) State(name State, opts ...StateOption) *Builder {
+	s := b.declare(name, opts...)
+	if s != nil && s.parent != nil && s.parent.history != 0 {
+		b.problem("state %q nested in history state %q", name, s.parent.name)
+	}
+	return b
+}
+
+// Error declares the error state. A [Machine] enters its error state when an [Action] fails.
+func (b *Builder) Error(name State, opts ...StateOption) *Builder {
+	if b.failed != "" {
+		b.problem("error state declared twice")
+		return b
+	}
+	b.failed = name
+	if s := b.declare(name, opts...); s != nil {
+		s.failed = true
+	}
+	return b
+}
+
+// History declares a history pseudo-state of a parent state.
+// A transition to a history pseudo-state restores the children (for [Shallow] history)
+// or descendants (for [Deep] history) of the parent that were active when it was last exited.
+// If the parent hasn't been exited, its initial child is entered.
+func (b *Builder) History(name State, parent State, kind HistoryKind) *Builder {
+	if kind != Shallow && kind != Deep {
+		b.problem("history state %q: unknown kind %d", name, kind)
+		return b
+	}
+	if s := b.declare(name, In(parent)); s != nil {
+		s.history = kind
+	}
+	return b
+}
+
+func (b *Builder) declare(name State, opts ...StateOption) *state {
+	if _, ok := b.states[name]; ok {
+		b.problem("state %q declared twice", name)
+		return nil
+	}
+
+	s := &state{
+		name:  name,
+		index: len(b.order),
+		edges: lift.NewMap[[]*transition](),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	if s.parentName != "" {
+		p, ok := b.states[s.parentName]
+		if !ok {
+			b.problem("state %q nested in undeclared state %q", name, s.parentName)
+			return nil
+		}
+		s.parent = p
+		p.children = append(p.children, s)
+	}
+
+	b.states[name] = s
+	b.order = append(b.order, s)
+	return s
+}
+
+// Inputs declares input flavors that every state, excepting final states, must handle.
+// An input is handled by a state if the state, one of its ancestors,
+// or a state in an orthogonal region handles it.
+func (b *Builder) Inputs(inputs ...lift.Sym) *Builder {
+	b.inputs = append(b.inputs, inputs...)
+	return b
+}
+
+// A TransitionOption configures a transition declared by [Builder.On].
+type TransitionOption func(*transition)
+
+// When sets a [Guard] on a transition. A transition is only taken when its guard permits it.
+func When(g Guard) TransitionOption {
+	return func(t *transition) {
+		t.guard = g
+	}
+}
+
+// Do adds an [Action] run when a transition is taken,
+// after exiting the source state and before entering the destination state.
+// Actions added by several Do options run in order, until one fails.
+func Do(fn Action) TransitionOption {
+	return func(t *transition) {
+		prev := t.action
+		if prev == nil {
+			t.action = fn
+			return
+		}
+		t.action = func(in lift.Sym) error {
+			if err := prev(in); err != nil {
+				return err
+			}
+			return fn(in)
+		}
+	}
+}
+
+// On declares a transition between states, keyed by the flavor of an input.
+// When several transitions share a state and input flavor,
+// the first declared transition permitted by its [Guard] is taken.
+//
+// A transition leaves the source state and its descendants, even when the
+// destination is the source state or one of its descendants.
+func (b *Builder) On(from State, input lift.Sym, to State, opts ...TransitionOption) *Builder {
+	src, ok := b.states[from]
+	if !ok {
+		b.problem("transition from undeclared state %q", from)
+		return b
+	}
+	if src.history != 0 {
+		b.problem("transition from history state %q", from)
+		return b
+	}
+	dst, ok := b.states[to]
+	if !ok {
+		b.problem("transition to undeclared state %q", to)
+		return b
+	}
+
+	t := &transition{
+		input: input,
+		from:  src,
+		to:    dst,
+	}
+	for _, opt := range opts {
+		opt(t)
+	}
+
+	ts, _ := lift.LoadSym(src.edges, input)
+	if len(ts) == 0 {
+		src.inputs = append(src.inputs, input)
+	}
+	src.edges.Store(lift.DefSym(input, append(ts, t)))
+	return b
+}
+
+// Build validates declarations, and returns a [Machine] in its initial state.
+// Undeclared states, unreachable states, and inputs declared by [Builder.Inputs]
+// that a state doesn't handle are reported as errors.
+func (b *Builder) Build() (*Machine, error) {
+	problems := append([]string(nil), b.problems...)
+
+	for _, s := range b.order {
+		if s.initial == "" {
+			continue
+		}
+		if c, ok := b.states[s.initial]; !ok || c.parent != s || c.history != 0 {
+			problems = append(problems, fmt.Sprintf("state %q: initial state %q is not a child", s.name, s.initial))
+		}
+	}
+
+	for _, s := range b.order {
+		if s.history != 0 && s.parent.atomic() {
+			problems = append(problems, fmt.Sprintf("history state %q: state %q has no children", s.name, s.parent.name))
+		}
+	}
+
+	initial, ok := b.states[b.initial]
+	switch {
+	case !ok:
+		problems = append(problems, fmt.Sprintf("undeclared initial state %q", b.initial))
+	case initial.history != 0:
+		problems = append(problems, fmt.Sprintf("initial state %q is a history state", b.initial))
+	case len(problems) == 0:
+		reached := b.reach(initial)
+		for _, s := range b.order {
+			if !reached[s] && s.history == 0 {
+				problems = append(problems, fmt.Sprintf("unreachable state %q", s.name))
+			}
+		}
+	}
+
+	for _, s := range b.order {
+		if s.final || s.history != 0 || !s.atomic() {
+			continue
+		}
+		for _, input := range b.inputs {
+			if !s.handles(input) {
+				problems = append(problems, fmt.Sprintf("state %q: unhandled input %s", s.name, lift.ReflectType(input)))
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("fsm: %s", strings.Join(problems, "; "))
+	}
+
+	m := &Machine{
+		states:   b.states,
+		order:    b.order,
+		initial:  initial,
+		failed:   b.states[b.failed],
+		active:   make(map[*state]bool),
+		recorded: make(map[*state][]*state),
+	}
+	m.enter(m.entrySet(initial, nil), nil)
+	return m, nil
+}
+
+func (b *Builder) problem(format string, args ...any) {
+	b.problems = append(b.problems, fmt.Sprintf(format, args...))
+}
+
+// reach collects states reachable from the initial state, or from the error state.
+func (b *Builder) reach(initial *state) map[*state]bool {
+	m := &Machine{recorded: make(map[*state][]*state)}
+	reached := make(map[*state]bool)
+
+	var queue []*state
+	visit := func(set map[*state]bool) {
+		for s := range set {
+			if !reached[s] {
+				reached[s] = true
+				queue = append(queue, s)
+			}
+		}
+	}
+
+	visit(m.entrySet(initial, nil))
+	if failed, ok := b.states[b.failed]; ok {
+		visit(m.entrySet(failed, nil))
+	}
+	for len(queue) > 0 {
+		s := queue[0]
+		queue = queue[1:]
+		for _, input := range s.inputs {
+			ts, _ := lift.LoadSym(s.edges, input)
+			for _, t := range ts {
+				visit(m.entrySet(t.to, t.domain()))
+			}
+		}
+	}
+	return reached
+}
+
+// STATE TREE
+
+// atomic reports whether a state has no children, excepting history pseudo-states.
+func (s *state) atomic() bool {
+	for _, c := range s.children {
+		if c.history == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// within reports whether s is a proper descendant of a.
+// Every state is within the nil root.
+func (s *state) within(a *state) bool {
+	if a == nil {
+		return true
+	}
+	for p := s.parent; p != nil; p = p.parent {
+		if p == a {
+			return true
+		}
+	}
+	return false
+}
+
+// defaultChild returns the child entered by default.
+func (s *state) defaultChild() *state {
+	for _, c := range s.children {
+		if c.history != 0 {
+			continue
+		}
+		if s.initial == "" || c.name == s.initial {
+			return c
+		}
+	}
+	return nil
+}
+
+// handles reports whether a state, an ancestor, or a state in an orthogonal region handles an input.
+func (s *state) handles(input lift.Sym) bool {
+	for a := s; a != nil; a = a.parent {
+		if _, ok := lift.LoadSym(a.edges, input); ok {
+			return true
+		}
+		if a.parallel && a.subtreeHandles(input) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *state) subtreeHandles(input lift.Sym) bool {
+	if _, ok := lift.LoadSym(s.edges, input); ok {
+		return true
+	}
+	for _, c := range s.children {
+		if c.subtreeHandles(input) {
+			return true
+		}
+	}
+	return false
+}
+
+// domain returns the state containing all states exited and entered by a transition.
+// A nil domain is the root of the state tree.
+func (t *transition) domain() *state {
+	for a := t.from.parent; a != nil; a = a.parent {
+		if !a.parallel && t.to.within(a) {
+			return a
+		}
+	}
+	return nil
+}
+
+// MACHINE
+
+// A Machine is a finite state machine, declared by a [Builder].
+// A Machine is not safe for concurrent use.
+type Machine struct {
+	states   map[State]*state
+	order    []*state
+	initial  *state
+	failed   *state
+	active   map[*state]bool
+	recorded map[*state][]*state
+	err      error
+}
+
+// State returns the current state of a [Machine]. When several states are active,
+// State returns the first declared active state without active children.
+func (m *Machine) State() State {
+	for _, s := range m.order {
+		if m.active[s] && s.atomic() {
+			return s.name
+		}
+	}
+	return ""
+}
+
+// Active returns every active state of a [Machine], in order of declaration.
+func (m *Machine) Active() []State {
+	var names []State
+	for _, s := range m.order {
+		if m.active[s] {
+			names = append(names, s.name)
+		}
+	}
+	return names
+}
+
+// In reports whether a state is active.
+func (m *Machine) In(name State) bool {
+	s, ok := m.states[name]
+	return ok && m.active[s]
+}
+
+// Err returns the error of the most recently failed [Action], if any.
+func (m *Machine) Err() error {
+	return m.err
+}
+
+// Fire feeds an input to a [Machine]. It reports whether a transition was taken.
+//
+// Each active state without active children selects a transition, from itself or
+// its nearest ancestor with a transition permitted for the input. Selected transitions
+// are taken in order of declaration, skipping any that would exit a state already exited.
+//
+// If an [Action] fails, the [Machine] enters its error state. Without an error state,
+// the [Machine] stays in the state it was in when the [Action] failed.
+func (m *Machine) Fire(in lift.Sym) bool {
+	var selected []*transition
+	exited := make(map[*state]bool)
+	enabled := make(map[*state]*transition)
+
+	for _, s := range m.order {
+		if !m.active[s] || !s.atomic() {
+			continue
+		}
+		for a := s; a != nil; a = a.parent {
+			t, ok := enabled[a]
+			if !ok {
+				t = a.enabled(in)
+				enabled[a] = t
+			}
+			if t == nil {
+				continue
+			}
+
+			exits := m.exitSet(t)
+			if !overlaps(exits, exited) {
+				for _, x := range exits {
+					exited[x] = true
+				}
+				selected = append(selected, t)
+			}
+			break
+		}
+	}
+
+	for _, t := range selected {
+		if !m.take(t, in) {
+			break
+		}
+	}
+	return len(selected) > 0
+}
+
+func (s *state) enabled(in lift.Sym) *transition {
+	ts, _ := lift.LoadSym(s.edges, in)
+	for _, t := range ts {
+		if t.guard == nil || t.guard(in) {
+			return t
+		}
+	}
+	return nil
+}
+
+func overlaps(states []*state, set map[*state]bool) bool {
+	for _, s := range states {
+		if set[s] {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *Machine) take(t *transition, in lift.Sym) bool {
+	exits := m.exitSet(t)
+	for _, s := range exits {
+		for _, c := range s.children {
+			if c.history != 0 {
+				m.record(c)
+			}
+		}
+	}
+	// states are deactivated once every exit action and the transition action succeed,
+	// so a failing action leaves the configuration intact
+	for _, s := range exits {
+		if !m.run(s.exit, in) {
+			return false
+		}
+	}
+	if !m.run(t.action, in) {
+		return false
+	}
+	for _, s := range exits {
+		delete(m.active, s)
+	}
+	return m.enter(m.entrySet(t.to, t.domain()), in)
+}
+
+// exitSet returns the active states exited by a transition, deepest first.
+func (m *Machine) exitSet(t *transition) []*state {
+	domain := t.domain()
+	var exits []*state
+	for i := len(m.order) - 1; i >= 0; i-- {
+		if s := m.order[i]; m.active[s] && s.within(domain) {
+			exits = append(exits, s)
+		}
+	}
+	return exits
+}
+
+// record records the active children or descendants of the parent of a history pseudo-state.
+func (m *Machine) record(h *state) {
+	var states []*state
+	for _, s := range m.order {
+		if !m.active[s] {
+			continue
+		}
+		if h.history == Shallow && s.parent == h.parent {
+			states = append(states, s)
+		}
+		if h.history == Deep && s.within(h.parent) && s.atomic() {
+			states = append(states, s)
+		}
+	}
+	m.recorded[h] = states
+}
+
+// entrySet returns the states entered by a transition to target, with the given domain.
+func (m *Machine) entrySet(target *state, domain *state) map[*state]bool {
+	set := make(map[*state]bool)
+	m.addDescendants(target, set)
+	m.addAncestors(target, domain, set)
+	return set
+}
+
+func (m *Machine) addDescendants(s *state, set map[*state]bool) {
+	if s.history != 0 {
+		states, ok := m.recorded[s]
+		if !ok {
+			states = []*state{s.parent.defaultChild()}
+		}
+		for _, r := range states {
+			m.addDescendants(r, set)
+		}
+		for _, r := range states {
+			m.addAncestors(r, s.parent, set)
+		}
+		return
+	}
+
+	set[s] = true
+	switch {
+	case s.parallel:
+		for _, c := range s.children {
+			if c.history == 0 && !hasDescendant(c, set) {
+				m.addDescendants(c, set)
+			}
+		}
+	case !s.atomic():
+		m.addDescendants(s.defaultChild(), set)
+	}
+}
+
+func (m *Machine) addAncestors(s *state, domain *state, set map[*state]bool) {
+	for a := s.parent; a != nil && a != domain; a = a.parent {
+		set[a] = true
+		if !a.parallel {
+			continue
+		}
+		for _, c := range a.children {
+			if c.history == 0 && !hasDescendant(c, set) {
+				m.addDescendants(c, set)
+			}
+		}
+	}
+}
+
+// hasDescendant reports whether s, or a descendant of s, is in set.
+func hasDescendant(s *state, set map[*state]bool) bool {
+	for x := range set {
+		if x == s || x.within(s) {
+			return true
+		}
+	}
+	return false
+}
+
+// enter activates a set of states, in order of declaration.
+func (m *Machine) enter(set map[*state]bool, in lift.Sym) bool {
+	states := make([]*state, 0, len(set))
+	for s := range set {
+		states = append(states, s)
+	}
+	sort.Slice(states, func(i, j int) bool {
+		return states[i].index < states[j].index
+	})
+
+	for _, s := range states {
+		m.active[s] = true
+		if !m.run(s.entry, in) {
+			return false
+		}
+	}
+	return true
+}
+
+// run runs an action, entering the error state when the action fails.
+func (m *Machine) run(fn Action, in lift.Sym) bool {
+	if fn == nil {
+		return true
+	}
+	err := fn(in)
+	if err == nil {
+		return true
+	}
+
+	m.err = err
+	if m.failed != nil && !m.active[m.failed] {
+		// the error state and its ancestors are active before any entry action runs,
+		// so a failure while entering them doesn't enter the error state again
+		set := m.entrySet(m.failed, nil)
+		m.active = make(map[*state]bool)
+		for s := range set {
+			m.active[s] = true
+		}
+		m.enter(set, in)
+		m.err = err
+	}
+	return false
+}
@@ -0,0 +1,211 @@
+package fsm_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/AndrewHarrisSPU/lift"
+	"github.com/AndrewHarrisSPU/lift/fsm"
+)
+
+// Ensure that malformed declarations fail to build
+func TestBuildProblems(t *testing.T) {
+	builders := map[string]*fsm.Builder{
+		"undeclared initial": fsm.New("a"),
+		"declared twice":     fsm.New("a").State("a").State("a"),
+		"undeclared from":    fsm.New("a").State("a").On("b", lift.T[push](), "a"),
+		"undeclared to":      fsm.New("a").State("a").On("a", lift.T[push](), "b"),
+		"unhandled input":    fsm.New("a").State("a").Inputs(lift.T[push]()),
+	}
+
+	for name, b := range builders {
+		if _, err := b.Build(); err == nil {
+			t.Errorf("%s: missing error", name)
+		}
+	}
+
+	// final states need not handle inputs
+	if _, err := fsm.New("a").State("a", fsm.Final()).Inputs(lift.T[push]()).Build(); err != nil {
+		t.Errorf("final state: %v", err)
+	}
+}
+
+// Ensure entry and exit actions run in order
+func TestActionOrder(t *testing.T) {
+	var log []string
+	note := func(msg string) fsm.Action {
+		return func(lift.Sym) error {
+			log = append(log, msg)
+			return nil
+		}
+	}
+
+	m, err := fsm.New("a").
+		State("a", fsm.OnEntry(note("enter a")), fsm.OnExit(note("exit a"))).
+		State("b", fsm.OnEntry(note("enter b"))).
+		On("a", lift.T[push](), "b", fsm.Do(note("a->b"))).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m.Fire(lift.Wrap(push{}))
+	if got := fmt.Sprint(log); got != "[enter a exit a a->b enter b]" {
+		t.Errorf("got %s", got)
+	}
+}
+
+// Ensure deep history restores nested states, where shallow history restores children
+func TestHistory(t *testing.T) {
+	for _, kind := range []fsm.HistoryKind{fsm.Shallow, fsm.Deep} {
+		m, err := fsm.New("outer").
+			State("outer").
+			State("a", fsm.In("outer")).
+			State("b", fsm.In("outer")).
+			State("b1", fsm.In("b")).
+			State("b2", fsm.In("b")).
+			History("h", "outer", kind).
+			State("away").
+			On("a", lift.T[next](), "b").
+			On("b1", lift.T[next](), "b2").
+			On("outer", lift.T[pause](), "away").
+			On("away", lift.T[pause](), "h").
+			Build()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, in := range []lift.Sym{
+			lift.Wrap(next{}),
+			lift.Wrap(next{}),
+			lift.Wrap(pause{}),
+			lift.Wrap(pause{}),
+		} {
+			m.Fire(in)
+		}
+
+		want := fsm.State("b1")
+		if kind == fsm.Deep {
+			want = "b2"
+		}
+		if got := m.State(); got != want {
+			t.Errorf("kind %d: got %s, want %s", kind, got, want)
+		}
+	}
+}
+
+// Ensure unhandled inputs bubble to parents, and parents are exited by their transitions
+func TestBubbling(t *testing.T) {
+	var exits []string
+	exit := func(name string) fsm.StateOption {
+		return fsm.OnExit(func(lift.Sym) error {
+			exits = append(exits, name)
+			return nil
+		})
+	}
+
+	m, err := fsm.New("child").
+		State("parent", exit("parent")).
+		State("child", fsm.In("parent"), exit("child")).
+		State("other").
+		Inputs(lift.T[push]()).
+		On("parent", lift.T[push](), "other").
+		On("other", lift.T[push](), "other").
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !m.Fire(lift.Wrap(push{})) || m.State() != "other" {
+		t.Errorf("unbubbled input: in %s", m.State())
+	}
+	if got := fmt.Sprint(exits); got != "[child parent]" {
+		t.Errorf("exit order: got %s", got)
+	}
+}
+
+// Ensure malformed hierarchies fail to build
+func TestBuildHierarchyProblems(t *testing.T) {
+	builders := map[string]*fsm.Builder{
+		"undeclared parent": fsm.New("a").State("a", fsm.In("p")),
+		"initial not child": fsm.New("p").State("p", fsm.Initial("q")).State("a", fsm.In("p")).State("q"),
+		"childless history": fsm.New("p").State("p").History("h", "p", fsm.Shallow),
+		"history source":    fsm.New("p").State("p").State("a", fsm.In("p")).History("h", "p", fsm.Deep).On("h", lift.T[push](), "a"),
+		"history initial":   fsm.New("h").State("p").State("a", fsm.In("p")).History("h", "p", fsm.Deep),
+	}
+
+	for name, b := range builders {
+		if _, err := b.Build(); err == nil {
+			t.Errorf("%s: missing error", name)
+		}
+	}
+}
+
+// Ensure a failure while entering the error state doesn't enter it again
+func TestErrorStateEntryFails(t *testing.T) {
+	failing := false
+	enterP := func(lift.Sym) error {
+		if failing {
+			return fmt.Errorf("entry of p")
+		}
+		return nil
+	}
+	fail := func(lift.Sym) error {
+		failing = true
+		return fmt.Errorf("do")
+	}
+
+	m, err := fsm.New("q").
+		State("p", fsm.OnEntry(enterP)).
+		State("q", fsm.In("p")).
+		Error("bad", fsm.In("p")).
+		On("q", lift.T[push](), "q", fsm.Do(fail)).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m.Fire(lift.Wrap(push{}))
+	if m.State() != "bad" || m.Err() == nil || m.Err().Error() != "do" {
+		t.Errorf("state %q, error %v", m.State(), m.Err())
+	}
+}
+
+// Ensure a failing exit action without an error state leaves the machine in place
+func TestExitFailsWithoutErrorState(t *testing.T) {
+	m, err := fsm.New("a").
+		State("outer").
+		State("a", fsm.In("outer"), fsm.OnExit(func(lift.Sym) error { return fmt.Errorf("exit a") })).
+		State("b").
+		On("a", lift.T[push](), "b").
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m.Fire(lift.Wrap(push{}))
+	if m.State() != "a" || fmt.Sprint(m.Active()) != "[outer a]" || m.Err() == nil {
+		t.Errorf("state %q, active %v, error %v", m.State(), m.Active(), m.Err())
+	}
+}
+
+// Ensure a failing transition action, without an error state, leaves the machine where it was
+func TestActionFailsWithoutErrorState(t *testing.T) {
+	m, err := fsm.New("a").
+		State("a").
+		State("b").
+		On("a", lift.T[push](), "b", fsm.Do(func(lift.Sym) error { return fmt.Errorf("a->b") })).
+		On("a", lift.T[coin](), "a").
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m.Fire(lift.Wrap(push{}))
+	if m.State() != "a" || fmt.Sprint(m.Active()) != "[a]" || m.Err() == nil {
+		t.Errorf("state %q, active %v, error %v", m.State(), m.Active(), m.Err())
+	}
+	if !m.Fire(lift.Wrap(coin(1))) {
+		t.Errorf("later input ignored")
+	}
+}
@@ -0,0 +1,107 @@
+package lift
+
+import (
+	"fmt"
+)
+
+// LIFTING
+
+// Lift lifts a function of T to a function of [Sym].
+// The lifted function is successful when the [Sym] argument unwraps to a T, as in [Unwrap].
+func Lift[T any, R any](fn func(T) R) func(Sym) (R, bool) {
+	return func(sym Sym) (r R, ok bool) {
+		if t, ok := Unwrap[T](sym); ok {
+			return fn(t), true
+		}
+		return r, false
+	}
+}
+
+// LiftErr resembles [Lift], for fallible functions.
+// When the [Sym] argument fails to unwrap to a T, the lifted function returns an error.
+func LiftErr[T any, R any](fn func(T) (R, error)) func(Sym) (R, error) {
+	return func(sym Sym) (r R, err error) {
+		if t, ok := Unwrap[T](sym); ok {
+			return fn(t)
+		}
+		return r, fmt.Errorf("LiftErr: want %T, got %T", enum[T]{}, flavor(sym))
+	}
+}
+
+// LiftSym resembles [Lift], but the result is also lifted, using [Wrap].
+// The lifted function returns nil when the [Sym] argument fails to unwrap to a T.
+func LiftSym[T any, R any](fn func(T) R) func(Sym) Sym {
+	return func(sym Sym) Sym {
+		if t, ok := Unwrap[T](sym); ok {
+			return Wrap(fn(t))
+		}
+		return nil
+	}
+}
+
+// TUPLES
+
+// A Tuple2 groups two values, so that two-argument functions may be lifted with [Lift2].
+type Tuple2[A any, B any] struct {
+	A A
+	B B
+}
+
+// A Tuple3 groups three values, so that three-argument functions may be lifted with [Lift3].
+type Tuple3[A any, B any, C any] struct {
+	A A
+	B B
+	C C
+}
+
+// Wrap2 wraps two values as a [Tuple2].
+func Wrap2[A any, B any](a A, b B) Sym {
+	return Wrap(Tuple2[A, B]{a, b})
+}
+
+// Wrap3 wraps three values as a [Tuple3].
+func Wrap3[A any, B any, C any](a A, b B, c C) Sym {
+	return Wrap(Tuple3[A, B, C]{a, b, c})
+}
+
+// Lift2 resembles [Lift], for two-argument functions.
+// The lifted function is successful when the [Sym] argument was produced by [Wrap2].
+func Lift2[A any, B any, R any](fn func(A, B) R) func(Sym) (R, bool) {
+	return Lift(func(t Tuple2[A, B]) R {
+		return fn(t.A, t.B)
+	})
+}
+
+// Lift3 resembles [Lift], for three-argument functions.
+// The lifted function is successful when the [Sym] argument was produced by [Wrap3].
+func Lift3[A any, B any, C any, R any](fn func(A, B, C) R) func(Sym) (R, bool) {
+	return Lift(func(t Tuple3[A, B, C]) R {
+		return fn(t.A, t.B, t.C)
+	})
+}
+
+// COMBINATORS
+
+// Compose returns a function applying f, then g.
+func Compose[A any, B any, C any](f func(A) B, g func(B) C) func(A) C {
+	return func(a A) C {
+		return g(f(a))
+	}
+}
+
+// Curry transforms a two-argument function into a chain of one-argument functions.
+func Curry[A any, B any, R any](fn func(A, B) R) func(A) func(B) R {
+	return func(a A) func(B) R {
+		return func(b B) R {
+			return fn(a, b)
+		}
+	}
+}
+
+// flavor returns the type enumeration of a [Sym], tolerating nil.
+func flavor(sym Sym) Sym {
+	if sym == nil {
+		return nil
+	}
+	return sym.enum()
+}
@@ -10,6 +10,15 @@
 // The [Map] type is provided as an immediate and highly general application of type enumeration symbols,
 // using them as map keys.
 //
+// The [Union2], [Union3], and [Union4] types hold a wrapped value from a closed set of types.
+// Their Switch methods require a handler for every alternative.
+//
+// [Lift] and its relatives lift ordinary functions to functions consuming [Sym].
+//
+// [Call] invokes a wrapped function of any signature. As flavors can't be recovered
+// from reflection alone, result types must first be recorded with [Register];
+// Call reports an error for a function with an unregistered result type.
+//
 // Function-flavored type enumerations (e.g. from func(T), distinct from T) can predicate
 // runtime dispatch gadgetry. For example, the lift/conv package leverages lift to index
 // conversion functions from given source and destination types. Package examples explore
@@ -18,6 +27,7 @@ package lift
 
 import (
 	"fmt"
+	"reflect"
 )
 
 // GADGETS
@@ -26,6 +36,7 @@ import (
 type Sym interface {
 	enum() Sym
 	exfiltrate() any
+	rtype() reflect.Type
 }
 
 // enum is a T-flavored, internal type enumeration symbol.
@@ -45,6 +56,11 @@ func EnumIs[T any](sym Sym) bool {
 	return enum[T]{} == sym.enum()
 }
 
+// Flavor returns the type enumeration symbol of a [Sym], discarding any wrapped value.
+func Flavor(sym Sym) Sym {
+	return sym.enum()
+}
+
 // Any is the type enumeration symbol of interface{}
 var Any = enum[any]{}
 
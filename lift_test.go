@@ -50,3 +50,78 @@ func TestMapExtra(t *testing.T) {
 		t.Errorf("Map method failure")
 	}
 }
+
+// The zero Union holds nothing, and calls no handler
+func TestUnionZero(t *testing.T) {
+	var u lift.Union2[int, string]
+	if u.Sym() != nil {
+		t.Errorf("zero Union holds a value")
+	}
+	u.Switch(
+		func(int) { t.Errorf("handler called") },
+		func(string) { t.Errorf("handler called") },
+	)
+
+	if _, ok := lift.NewUnion4[int, string, bool, rune](lift.T[int]()); ok {
+		t.Errorf("Union from unwrapped Sym")
+	}
+}
+
+// Call reports signature mismatches as errors
+func TestCallErrors(t *testing.T) {
+	sum := lift.Wrap(func(base int, ns ...int) int {
+		for _, n := range ns {
+			base += n
+		}
+		return base
+	})
+
+	for _, args := range [][]lift.Sym{
+		{},
+		{lift.T[int]()},
+		{lift.Wrap(1), lift.Wrap(2.0)},
+		{nil},
+	} {
+		if _, err := lift.Call(sum, args...); err == nil {
+			t.Errorf("missing error for %v", args)
+		}
+	}
+
+	if _, err := lift.Call(lift.Wrap(0)); err == nil {
+		t.Errorf("missing error for non-func")
+	}
+	if _, err := lift.Call(lift.Wrap((func())(nil))); err == nil {
+		t.Errorf("missing error for nil func")
+	}
+
+	out, err := lift.Call(sum, lift.Wrap(1), lift.Wrap(2), lift.Wrap(3))
+	if err != nil || lift.MustUnwrap[int](out[0]) != 6 {
+		t.Errorf("variadic Call failure: %v", err)
+	}
+}
+
+// Ensure Call reports unregistered result types
+func TestCallResults(t *testing.T) {
+	// unknown is never registered, so repeated runs see it unregistered
+	type unknown struct{}
+	if out, err := lift.Call(lift.Wrap(func() unknown { return unknown{} })); err == nil {
+		t.Errorf("unregistered result: got %v", out)
+	}
+
+	type point struct{ x, y int }
+	fn := lift.Wrap(func() (point, error) {
+		return point{1, 2}, nil
+	})
+
+	lift.Register[point]()
+	out, err := lift.Call(fn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p, ok := lift.Unwrap[point](out[0]); !ok || p.y != 2 {
+		t.Errorf("registered result: got %v", out[0])
+	}
+	if !lift.EnumIs[error](out[1]) {
+		t.Errorf("error result: got %v", out[1])
+	}
+}
@@ -0,0 +1,137 @@
+package lift
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// REFLECTION
+
+func (e enum[T]) rtype() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+func (w wrapped[T]) rtype() reflect.Type {
+	return enum[T]{}.rtype()
+}
+
+// ReflectType returns the [reflect.Type] of the flavor of a [Sym].
+func ReflectType(sym Sym) reflect.Type {
+	return sym.rtype()
+}
+
+// ReflectValue returns a [reflect.Value] of a wrapped value. It is successful when
+// the [Sym] was produced by [Wrap]. The type of the [reflect.Value] is precisely
+// the flavor of the [Sym], even when that flavor is an interface type.
+func ReflectValue(sym Sym) (v reflect.Value, ok bool) {
+	if w, ok := sym.(interface{ value() reflect.Value }); ok {
+		return w.value(), true
+	}
+	return v, false
+}
+
+func (w wrapped[T]) value() reflect.Value {
+	return reflect.ValueOf(&w.t).Elem()
+}
+
+// wrappers holds functions wrapping a [reflect.Value] as a precisely flavored [Sym].
+var wrappers sync.Map // reflect.Type -> func(reflect.Value) Sym
+
+func init() {
+	Register[bool]()
+	Register[string]()
+	Register[int]()
+	Register[int8]()
+	Register[int16]()
+	Register[int32]()
+	Register[int64]()
+	Register[uint]()
+	Register[uint8]()
+	Register[uint16]()
+	Register[uint32]()
+	Register[uint64]()
+	Register[uintptr]()
+	Register[float32]()
+	Register[float64]()
+	Register[complex64]()
+	Register[complex128]()
+	Register[error]()
+	Register[any]()
+}
+
+// Register records type T, so that [WrapValue] can wrap values of type T
+// with the flavor of T. Predeclared types are registered by default.
+func Register[T any]() {
+	wrappers.Store(enum[T]{}.rtype(), func(v reflect.Value) Sym {
+		var t T
+		reflect.ValueOf(&t).Elem().Set(v)
+		return Wrap(t)
+	})
+}
+
+// WrapValue resembles [Wrap] for a [reflect.Value]. It is successful when
+// the type of the value was recorded by [Register].
+func WrapValue(v reflect.Value) (sym Sym, ok bool) {
+	if !v.IsValid() {
+		return nil, false
+	}
+	if wrap, ok := wrappers.Load(v.Type()); ok {
+		return wrap.(func(reflect.Value) Sym)(v), true
+	}
+	return nil, false
+}
+
+// Call invokes a function wrapped by fnSym, with arguments unwrapped from args.
+// Each argument must be produced by [Wrap], and its flavor must be assignable to the
+// corresponding parameter of the function. Mismatched signatures are reported as errors.
+//
+// Results are wrapped as in [WrapValue], with the flavor of each result type.
+// Result types must be known to [Register]; otherwise, Call reports an error
+// without invoking the function.
+func Call(fnSym Sym, args ...Sym) ([]Sym, error) {
+	fn, ok := ReflectValue(fnSym)
+	if !ok || fn.Kind() != reflect.Func {
+		return nil, fmt.Errorf("Call: want wrapped func, got %T", flavor(fnSym))
+	}
+	if fn.IsNil() {
+		return nil, fmt.Errorf("Call: %s: nil func", fn.Type())
+	}
+
+	ft := fn.Type()
+	if n := ft.NumIn(); len(args) != n && !(ft.IsVariadic() && len(args) >= n-1) {
+		return nil, fmt.Errorf("Call: %s: want %d arguments, got %d", ft, n, len(args))
+	}
+
+	in := make([]reflect.Value, len(args))
+	for i, arg := range args {
+		var want reflect.Type
+		if ft.IsVariadic() && i >= ft.NumIn()-1 {
+			want = ft.In(ft.NumIn() - 1).Elem()
+		} else {
+			want = ft.In(i)
+		}
+
+		v, ok := ReflectValue(arg)
+		if !ok {
+			return nil, fmt.Errorf("Call: %s: argument %d: want wrapped %s, got %T", ft, i, want, flavor(arg))
+		}
+		if !v.Type().AssignableTo(want) {
+			return nil, fmt.Errorf("Call: %s: argument %d: want %s, got %s", ft, i, want, v.Type())
+		}
+		in[i] = v
+	}
+
+	for i := 0; i < ft.NumOut(); i++ {
+		if _, ok := wrappers.Load(ft.Out(i)); !ok {
+			return nil, fmt.Errorf("Call: %s: result %d: %s is not registered", ft, i, ft.Out(i))
+		}
+	}
+
+	out := fn.Call(in)
+	results := make([]Sym, len(out))
+	for i, v := range out {
+		results[i], _ = WrapValue(v)
+	}
+	return results, nil
+}
@@ -0,0 +1,119 @@
+package lift
+
+// UNION
+
+// A Union2 holds a value of exactly one of the types A or B.
+// The zero Union2 holds no value.
+type Union2[A, B any] struct {
+	sym Sym
+}
+
+// NewUnion2 returns a [Union2] holding the value wrapped by sym.
+// It is successful when sym was produced by [Wrap], and the wrapped flavor
+// precisely matches one of A or B.
+func NewUnion2[A, B any](sym Sym) (u Union2[A, B], ok bool) {
+	if is[A](sym) || is[B](sym) {
+		return Union2[A, B]{sym}, true
+	}
+	return u, false
+}
+
+// Sym returns the wrapped value held by a [Union2], or nil for the zero Union2.
+func (u Union2[A, B]) Sym() Sym {
+	return u.sym
+}
+
+// Alternatives returns the type enumeration symbols of the member types of a [Union2].
+func (u Union2[A, B]) Alternatives() []Sym {
+	return []Sym{enum[A]{}, enum[B]{}}
+}
+
+// Switch calls the handler matching the type of the held value.
+// Every alternative requires a handler; none is called for the zero Union2.
+func (u Union2[A, B]) Switch(a func(A), b func(B)) {
+	if t, ok := Unwrap[A](u.sym); ok {
+		a(t)
+	} else if t, ok := Unwrap[B](u.sym); ok {
+		b(t)
+	}
+}
+
+// A Union3 holds a value of exactly one of the types A, B, or C.
+// The zero Union3 holds no value.
+type Union3[A, B, C any] struct {
+	sym Sym
+}
+
+// NewUnion3 resembles [NewUnion2], for a [Union3].
+func NewUnion3[A, B, C any](sym Sym) (u Union3[A, B, C], ok bool) {
+	if is[A](sym) || is[B](sym) || is[C](sym) {
+		return Union3[A, B, C]{sym}, true
+	}
+	return u, false
+}
+
+// Sym returns the wrapped value held by a [Union3], or nil for the zero Union3.
+func (u Union3[A, B, C]) Sym() Sym {
+	return u.sym
+}
+
+// Alternatives returns the type enumeration symbols of the member types of a [Union3].
+func (u Union3[A, B, C]) Alternatives() []Sym {
+	return []Sym{enum[A]{}, enum[B]{}, enum[C]{}}
+}
+
+// Switch calls the handler matching the type of the held value.
+// Every alternative requires a handler; none is called for the zero Union3.
+func (u Union3[A, B, C]) Switch(a func(A), b func(B), c func(C)) {
+	if t, ok := Unwrap[A](u.sym); ok {
+		a(t)
+	} else if t, ok := Unwrap[B](u.sym); ok {
+		b(t)
+	} else if t, ok := Unwrap[C](u.sym); ok {
+		c(t)
+	}
+}
+
+// A Union4 holds a value of exactly one of the types A, B, C, or D.
+// The zero Union4 holds no value.
+type Union4[A, B, C, D any] struct {
+	sym Sym
+}
+
+// NewUnion4 resembles [NewUnion2], for a [Union4].
+func NewUnion4[A, B, C, D any](sym Sym) (u Union4[A, B, C, D], ok bool) {
+	if is[A](sym) || is[B](sym) || is[C](sym) || is[D](sym) {
+		return Union4[A, B, C, D]{sym}, true
+	}
+	return u, false
+}
+
+// Sym returns the wrapped value held by a [Union4], or nil for the zero Union4.
+func (u Union4[A, B, C, D]) Sym() Sym {
+	return u.sym
+}
+
+// Alternatives returns the type enumeration symbols of the member types of a [Union4].
+func (u Union4[A, B, C, D]) Alternatives() []Sym {
+	return []Sym{enum[A]{}, enum[B]{}, enum[C]{}, enum[D]{}}
+}
+
+// Switch calls the handler matching the type of the held value.
+// Every alternative requires a handler; none is called for the zero Union4.
+func (u Union4[A, B, C, D]) Switch(a func(A), b func(B), c func(C), d func(D)) {
+	if t, ok := Unwrap[A](u.sym); ok {
+		a(t)
+	} else if t, ok := Unwrap[B](u.sym); ok {
+		b(t)
+	} else if t, ok := Unwrap[C](u.sym); ok {
+		c(t)
+	} else if t, ok := Unwrap[D](u.sym); ok {
+		d(t)
+	}
+}
+
+// is reports whether sym wraps a value of precisely type T.
+func is[T any](sym Sym) bool {
+	_, ok := sym.(wrapped[T])
+	return ok
+}